@@ -2,49 +2,239 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/samvad-hq/samvad-news-harvester/internal/app"
 	"github.com/samvad-hq/samvad-news-harvester/internal/config"
 	"github.com/samvad-hq/samvad-news-harvester/internal/logger"
+	"github.com/samvad-hq/samvad-news-harvester/pkg/providers"
 )
 
+// defaultDiscoverUserAgent identifies discovery probes when -user-agent is not given.
+const defaultDiscoverUserAgent = "samvad-news-harvester (+https://github.com/samvad-hq/samvad-news-harvester)"
+
 func main() {
-	if err := run(); err != nil {
+	if err := run(os.Args[1:]); err != nil {
 		fmt.Fprintf(os.Stderr, "harvester start failed: %v\n", err)
 		os.Exit(1)
 	}
 }
 
-func run() error {
-	cfg, err := config.Load()
+// run starts the crawl loop, or the subcommand named by the first argument.
+func run(args []string) error {
+	if len(args) > 0 {
+		switch args[0] {
+		case "backfill":
+			return runBackfill(args[1:])
+		case "providers":
+			return runProviders(args[1:])
+		default:
+			return fmt.Errorf("unknown command %q (expected no arguments, backfill or providers)", args[0])
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	harvester, err := newHarvester(ctx)
 	if err != nil {
-		return fmt.Errorf("load config: %w", err)
+		return err
 	}
+	defer logger.Close()
 
-	log, err := logger.Init(cfg)
+	if err := harvester.Run(ctx); err != nil {
+		return fmt.Errorf("harvester run: %w", err)
+	}
+
+	return nil
+}
+
+// runBackfill publishes a provider's archived articles for a day range.
+func runBackfill(args []string) error {
+	fs := flag.NewFlagSet("backfill", flag.ContinueOnError)
+	providerID := fs.String("provider", "", "provider id to backfill")
+	from := fs.String("from", "", "first day to backfill (YYYY-MM-DD, provider timezone)")
+	to := fs.String("to", "", "last day to backfill (YYYY-MM-DD, provider timezone; defaults to -from)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *providerID == "" || *from == "" {
+		return errors.New("backfill requires -provider and -from")
+	}
+	if *to == "" {
+		*to = *from
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	harvester, err := newHarvester(ctx)
 	if err != nil {
-		return fmt.Errorf("init logger: %w", err)
+		return err
 	}
 	defer logger.Close()
 
-	logger.InfoObj("harvester starting", "config", cfg)
+	if err := harvester.Backfill(ctx, *providerID, *from, *to); err != nil {
+		return fmt.Errorf("backfill: %w", err)
+	}
+	return nil
+}
+
+// providersUsage lists the providers subcommands.
+const providersUsage = `usage:
+  harvester providers discover [-user-agent UA] <site-url>
+  harvester providers import [-user-agent UA] <file.opml>
+  harvester providers export [-title TITLE]`
+
+// runProviders dispatches the providers subcommands.
+func runProviders(args []string) error {
+	if len(args) == 0 {
+		return errors.New(providersUsage)
+	}
+	switch args[0] {
+	case "discover":
+		return runDiscover(args[1:], os.Stdout, os.Stderr)
+	case "import":
+		return runImportOPML(args[1:], os.Stdout)
+	case "export":
+		return runExportOPML(args[1:], os.Stdout, os.Stderr)
+	default:
+		return errors.New(providersUsage)
+	}
+}
+
+// runDiscover prints ready-to-paste provider entries for the feeds and sitemaps a site advertises.
+// Candidates that returned no articles are reported on errOut.
+func runDiscover(args []string, out, errOut io.Writer) error {
+	fs := flag.NewFlagSet("discover", flag.ContinueOnError)
+	userAgent := fs.String("user-agent", defaultDiscoverUserAgent, "User-Agent sent while probing and written to config.user_agent")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errors.New(providersUsage)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
 
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
-	harvester, err := app.NewHarvester(ctx, cfg, log)
+	candidates, err := app.DiscoverProviders(ctx, cfg, fs.Arg(0), *userAgent)
+	if err != nil {
+		return fmt.Errorf("discover: %w", err)
+	}
+
+	var found []providers.Provider
+	var comments []string
+	for _, c := range candidates {
+		if c.Err != nil {
+			fmt.Fprintf(errOut, "skipped %s %s: %v\n", c.Provider.Type, c.Provider.SourceURL, c.Err)
+			continue
+		}
+		found = append(found, c.Provider)
+		comments = append(comments, fmt.Sprintf("%d articles found", c.Articles))
+	}
+	if len(found) == 0 {
+		return fmt.Errorf("discover: none of the %d candidates returned articles", len(candidates))
+	}
+
+	data, err := providers.MarshalProvidersYAML(found, comments)
 	if err != nil {
-		logger.ErrorObj("failed to initialize harvester", "error", err)
 		return err
 	}
+	_, err = out.Write(data)
+	return err
+}
 
-	if err := harvester.Run(ctx); err != nil {
-		return fmt.Errorf("harvester run: %w", err)
+// runImportOPML prints provider entries for the feeds of an OPML file.
+func runImportOPML(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	userAgent := fs.String("user-agent", "", "value written to config.user_agent of every entry")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errors.New(providersUsage)
 	}
 
-	return nil
+	data, err := os.ReadFile(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("read opml: %w", err)
+	}
+	imported, err := providers.ImportOPML(data, *userAgent)
+	if err != nil {
+		return err
+	}
+	yamlData, err := providers.MarshalProvidersYAML(imported, nil)
+	if err != nil {
+		return err
+	}
+	_, err = out.Write(yamlData)
+	return err
+}
+
+// runExportOPML prints the configured providers file as OPML. Providers that are not single feeds are
+// reported on errOut.
+func runExportOPML(args []string, out, errOut io.Writer) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	title := fs.String("title", "samvad-news-harvester providers", "OPML head title")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return errors.New(providersUsage)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	reg, err := providers.LoadRegistry(cfg.ProvidersFile)
+	if err != nil {
+		return fmt.Errorf("load providers registry: %w", err)
+	}
+
+	data, skipped, err := providers.ExportOPML(reg.All(), *title, time.Now())
+	if err != nil {
+		return err
+	}
+	for _, id := range skipped {
+		fmt.Fprintf(errOut, "skipped %s: not a single rss/atom feed\n", id)
+	}
+	_, err = out.Write(data)
+	return err
+}
+
+// newHarvester loads config, initializes the logger and builds the harvester runtime.
+func newHarvester(ctx context.Context) (*app.Harvester, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+
+	log, err := logger.Init(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("init logger: %w", err)
+	}
+
+	logger.InfoObj("harvester starting", "config", cfg)
+
+	harvester, err := app.NewHarvester(ctx, cfg, log)
+	if err != nil {
+		logger.ErrorObj("failed to initialize harvester", "error", err)
+		logger.Close()
+		return nil, err
+	}
+	return harvester, nil
 }
@@ -2,17 +2,27 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/samvad-hq/samvad-news-harvester/internal/config"
 	"github.com/samvad-hq/samvad-news-harvester/internal/crawler"
 	"github.com/samvad-hq/samvad-news-harvester/internal/logger"
 	"github.com/samvad-hq/samvad-news-harvester/internal/storage"
+	"github.com/samvad-hq/samvad-news-harvester/internal/websub"
+	"github.com/samvad-hq/samvad-news-harvester/pkg/httpclient"
 	"github.com/samvad-hq/samvad-news-harvester/pkg/providers"
 	"github.com/samvad-hq/samvad-news-harvester/pkg/publishers"
+	"github.com/samvad-hq/samvad-news-harvester/pkg/robots"
 )
 
+// websubShutdownTimeout bounds how long the callback listener waits for in-flight requests on shutdown.
+const websubShutdownTimeout = 10 * time.Second
+
 // Harvester represents the news harvester runtime. It manages the crawl loop,
 // coordinating between providers, the crawler service, and publishers. It also
 // handles storage initialization and cleanup.
@@ -24,6 +34,8 @@ type Harvester struct {
 	crawlInterval time.Duration
 	log           logger.Logger
 	store         storage.Store
+	client        httpclient.Client
+	websub        *websub.Subscriber
 }
 
 // NewHarvester builds a harvester runtime from config files.
@@ -56,7 +68,6 @@ func NewHarvester(ctx context.Context, cfg *config.Config, log logger.Logger) (*
 	if err != nil {
 		return nil, fmt.Errorf("load publishers registry: %w", err)
 	}
-	providerRegistry := providers.DefaultFetcherRegistry(nil)
 
 	enabledPublishers := publisherReg.Enabled()
 	if len(enabledPublishers) == 0 {
@@ -96,7 +107,26 @@ func NewHarvester(ctx context.Context, cfg *config.Config, log logger.Logger) (*
 		"cleanup_interval_seconds": int(cfg.StorageCleanupInterval.Seconds()),
 	})
 
-	crawlService := crawler.NewService(providerRegistry, fanout, log, store)
+	baseClient := providers.DefaultHTTPClient()
+	client := withRobots(cfg, baseClient)
+	log.InfoObj("robots policy configured", "robots_config", map[string]any{
+		"respect_robots":    cfg.RespectRobots,
+		"cache_ttl_seconds": int(cfg.RobotsCacheTTL.Seconds()),
+	})
+
+	providerRegistry := providers.DefaultFetcherRegistry(client, store)
+	crawlService := crawler.NewService(providerRegistry, client, fanout, log, store)
+
+	var subscriber *websub.Subscriber
+	if cfg.WebSubCallbackURL != "" {
+		// Hub discovery and subscription requests are not crawling, so they bypass the robots.txt policy.
+		subscriber = websub.NewSubscriber(baseClient, crawlService, log, cfg.WebSubCallbackURL, cfg.WebSubSecret, cfg.WebSubLease)
+		log.InfoObj("websub configured", "websub_config", map[string]any{
+			"callback_url":  cfg.WebSubCallbackURL,
+			"listen_addr":   cfg.WebSubListenAddr,
+			"lease_seconds": int(cfg.WebSubLease.Seconds()),
+		})
+	}
 
 	return &Harvester{
 		cfg:           cfg,
@@ -106,6 +136,8 @@ func NewHarvester(ctx context.Context, cfg *config.Config, log logger.Logger) (*
 		crawlInterval: cfg.CrawlInterval,
 		log:           log,
 		store:         store,
+		client:        client,
+		websub:        subscriber,
 	}, nil
 }
 
@@ -122,6 +154,16 @@ func (h *Harvester) Run(ctx context.Context) error {
 		return ctx.Err()
 	}
 
+	if h.websub != nil {
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			h.serveWebSub(ctx, providers)
+		}()
+		// Runs before closeStore so pushes still being processed can use the store.
+		defer func() { <-done }()
+	}
+
 	h.log.InfoObj("harvester loop starting", "harvester_state", map[string]any{
 		"providers_count":  len(providers),
 		"publishers_count": h.fanout.Size(),
@@ -148,6 +190,85 @@ func (h *Harvester) Run(ctx context.Context) error {
 	}
 }
 
+// Backfill publishes a provider's archived articles for the inclusive from..to day range (YYYY-MM-DD, in
+// the provider's timezone). Requests are spaced by the provider's request_delay_ms, conditional GETs are
+// skipped, and completed sources are checkpointed until the backfill finishes, so a rerun over the same or
+// an overlapping range resumes where it stopped.
+func (h *Harvester) Backfill(ctx context.Context, providerID, from, to string) error {
+	if h == nil || h.providerReg == nil {
+		return fmt.Errorf("harvester is not initialized")
+	}
+	defer h.closeStore()
+
+	cfg, ok := h.providerReg.ByID(providerID)
+	if !ok {
+		return fmt.Errorf("provider %q not found in %s", providerID, h.cfg.ProvidersFile)
+	}
+	rng, err := providers.ParseBackfillRange(cfg, from, to)
+	if err != nil {
+		return err
+	}
+
+	// The planning read of a plain sitemap is replayed to its fetch rather than downloaded twice.
+	client := httpclient.NewReplayClient(httpclient.NewThrottledClient(h.client, cfg.RequestDelay()))
+	backfill := crawler.NewBackfill(providers.DefaultFetcherRegistry(client, nil), client, h.fanout, h.log, h.store, h.store)
+
+	h.log.InfoObj("backfill starting", "backfill_meta", map[string]any{
+		"provider_id":      cfg.ID,
+		"range":            rng.String(),
+		"request_delay_ms": cfg.RequestDelay().Milliseconds(),
+	})
+	return backfill.Run(ctx, cfg, rng)
+}
+
+// serveWebSub serves hub callbacks on the configured address and keeps push subscriptions for providers
+// alive until ctx is cancelled. Polling continues regardless, so a hub that stops pushing only costs latency.
+func (h *Harvester) serveWebSub(ctx context.Context, providers []providers.Provider) {
+	callback, err := url.Parse(h.cfg.WebSubCallbackURL)
+	if err != nil {
+		h.log.ErrorObj("websub disabled", "error", err)
+		return
+	}
+	mux := http.NewServeMux()
+	mux.Handle(strings.TrimRight(callback.Path, "/")+"/", h.websub)
+	srv := &http.Server{Addr: h.cfg.WebSubListenAddr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			h.log.ErrorObj("websub listener failed", "error", err)
+		}
+	}()
+
+	if err := h.websub.Run(ctx, providers); err != nil {
+		h.log.ErrorObj("websub subscriber stopped", "error", err)
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), websubShutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		h.log.ErrorObj("websub listener shutdown failed", "error", err)
+	}
+	h.websub.Wait()
+}
+
+// DiscoverProviders proposes provider entries for the site at siteURL and test-fetches each one. It needs
+// no providers or publishers files; robots.txt is honoured as configured.
+func DiscoverProviders(ctx context.Context, cfg *config.Config, siteURL, userAgent string) ([]providers.Candidate, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("config must not be nil")
+	}
+	client := withRobots(cfg, providers.DefaultHTTPClient())
+	return providers.Discover(ctx, client, providers.DefaultFetcherRegistry(client, nil), siteURL, userAgent)
+}
+
+// withRobots wraps client with the robots.txt policy when enabled.
+func withRobots(cfg *config.Config, client httpclient.Client) httpclient.Client {
+	if cfg.RespectRobots {
+		client = robots.NewClient(client, robots.NewChecker(client, cfg.RobotsCacheTTL))
+	}
+	return client
+}
+
 // runOnce performs a single crawl operation across all providers.
 func (h *Harvester) runOnce(ctx context.Context, providers []providers.Provider) error {
 	start := time.Now()
@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -24,6 +25,16 @@ type Config struct {
 	StorageCleanupSeconds  int64         `mapstructure:"storage_cleanup_interval_seconds"`
 	StorageTTL             time.Duration `mapstructure:"-"`
 	StorageCleanupInterval time.Duration `mapstructure:"-"`
+
+	RespectRobots         bool          `mapstructure:"respect_robots"`
+	RobotsCacheTTLSeconds int64         `mapstructure:"robots_cache_ttl_seconds"`
+	RobotsCacheTTL        time.Duration `mapstructure:"-"`
+
+	WebSubCallbackURL  string        `mapstructure:"websub_callback_url"`
+	WebSubListenAddr   string        `mapstructure:"websub_listen_addr"`
+	WebSubLeaseSeconds int64         `mapstructure:"websub_lease_seconds"`
+	WebSubSecret       string        `mapstructure:"websub_secret" json:"-"`
+	WebSubLease        time.Duration `mapstructure:"-"`
 }
 
 // Load reads configuration from environment variables and config files.
@@ -42,6 +53,12 @@ func Load() (*Config, error) {
 	v.SetDefault("bbolt_path", "./data/cache.db")
 	v.SetDefault("storage_ttl_seconds", int64((5*24*time.Hour)/time.Second))
 	v.SetDefault("storage_cleanup_interval_seconds", int64((12*time.Hour)/time.Second))
+	v.SetDefault("respect_robots", true)
+	v.SetDefault("robots_cache_ttl_seconds", int64((24*time.Hour)/time.Second))
+	v.SetDefault("websub_callback_url", "")
+	v.SetDefault("websub_listen_addr", ":8080")
+	v.SetDefault("websub_lease_seconds", int64((7*24*time.Hour)/time.Second))
+	v.SetDefault("websub_secret", "")
 
 	v.AutomaticEnv()
 
@@ -64,5 +81,25 @@ func Load() (*Config, error) {
 	cfg.StorageTTL = time.Duration(cfg.StorageTTLSeconds) * time.Second
 	cfg.StorageCleanupInterval = time.Duration(cfg.StorageCleanupSeconds) * time.Second
 
+	if cfg.RobotsCacheTTLSeconds <= 0 {
+		return nil, fmt.Errorf("invalid robots_cache_ttl_seconds (must be positive seconds)")
+	}
+	cfg.RobotsCacheTTL = time.Duration(cfg.RobotsCacheTTLSeconds) * time.Second
+
+	if cfg.WebSubCallbackURL != "" {
+		u, err := url.Parse(cfg.WebSubCallbackURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("invalid websub_callback_url (must be an absolute http(s) URL)")
+		}
+		// Without a secret anyone who can reach the callback could publish articles as a provider.
+		if cfg.WebSubSecret == "" || len(cfg.WebSubSecret) >= 200 {
+			return nil, fmt.Errorf("invalid websub_secret (required when websub_callback_url is set, under 200 bytes)")
+		}
+	}
+	if cfg.WebSubLeaseSeconds <= 0 {
+		return nil, fmt.Errorf("invalid websub_lease_seconds (must be positive seconds)")
+	}
+	cfg.WebSubLease = time.Duration(cfg.WebSubLeaseSeconds) * time.Second
+
 	return &cfg, nil
 }
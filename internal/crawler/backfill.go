@@ -0,0 +1,146 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/samvad-hq/samvad-news-harvester/internal/domain"
+	"github.com/samvad-hq/samvad-news-harvester/internal/logger"
+	"github.com/samvad-hq/samvad-news-harvester/pkg/httpclient"
+	"github.com/samvad-hq/samvad-news-harvester/pkg/providers"
+)
+
+// Backfill replays a provider's archive sources through the regular fetch, dedupe, enrich and publish
+// pipeline, checkpointing each completed source until the whole backfill succeeds.
+type Backfill struct {
+	processor   *ProviderProcessor
+	client      httpclient.Client
+	checkpoints BackfillCheckpoints
+	log         logger.Logger
+	now         func() time.Time
+}
+
+// NewBackfill builds a backfill runner. client is used both to plan sources and to scrape article pages,
+// so wrapping it with a throttle bounds the whole backfill. checkpoints may be nil to disable resuming.
+func NewBackfill(reg providers.FetcherRegistry, client httpclient.Client, pub EventPublisher, log logger.Logger, deduper ArticleDeduper, checkpoints BackfillCheckpoints) *Backfill {
+	if log == nil {
+		log = logger.NopLogger{}
+	}
+	if client == nil {
+		client = providers.DefaultHTTPClient()
+	}
+	scraper := NewScraper(client, log)
+	return &Backfill{
+		processor:   NewProviderProcessor(reg, scraper, pub, log, deduper),
+		client:      client,
+		checkpoints: checkpoints,
+		log:         log,
+		now:         time.Now,
+	}
+}
+
+// Run backfills cfg over rng, oldest source first, publishing only articles published within rng.
+// Sources completed by an earlier, unfinished run are skipped, even if its range differed. A failed
+// source is logged and left unchecked so the next run retries it; once every source has completed, the
+// provider's checkpoints are cleared.
+func (b *Backfill) Run(ctx context.Context, cfg providers.Provider, rng providers.BackfillRange) error {
+	if b == nil || b.processor == nil {
+		return fmt.Errorf("backfill not initialized")
+	}
+
+	start := b.now()
+	sources, err := providers.BackfillSources(ctx, b.client, cfg, rng)
+	if err != nil {
+		return fmt.Errorf("plan backfill for provider %s: %w", cfg.ID, err)
+	}
+	b.log.InfoObj("backfill planned", "backfill_plan", map[string]any{
+		"provider_id": cfg.ID,
+		"range":       rng.String(),
+		"sources":     len(sources),
+	})
+
+	var skipped, failed int
+	for i, source := range sources {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		key := providers.BackfillCheckpointKey(cfg, source.URL)
+		if b.checkpoints != nil {
+			done, err := b.checkpoints.BackfillDone(key)
+			if err != nil {
+				return fmt.Errorf("read backfill checkpoint: %w", err)
+			}
+			if done {
+				skipped++
+				continue
+			}
+		}
+
+		inRange := func(articles []domain.Article) ([]domain.Article, int) {
+			return dropOutOfRange(articles, rng, source.Dated)
+		}
+		if err := b.processor.process(ctx, providers.BackfillProvider(cfg, source.URL, rng, start), 0, dropReasonOutOfRange, inRange); err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return ctxErr
+			}
+			failed++
+			b.log.WarnObj("backfill source failed", "backfill_error", map[string]any{
+				"provider_id": cfg.ID,
+				"source":      source.URL,
+				"error":       err.Error(),
+			})
+			continue
+		}
+
+		if b.checkpoints != nil {
+			if err := b.checkpoints.MarkBackfillDone(key); err != nil {
+				return fmt.Errorf("save backfill checkpoint: %w", err)
+			}
+		}
+		b.log.InfoObj("backfill source completed", "backfill_progress", map[string]any{
+			"provider_id": cfg.ID,
+			"source":      source.URL,
+			"done":        i + 1,
+			"total":       len(sources),
+		})
+	}
+
+	if failed == 0 && b.checkpoints != nil {
+		if err := b.checkpoints.ClearBackfillDone(providers.BackfillCheckpointPrefix(cfg)); err != nil {
+			return fmt.Errorf("clear backfill checkpoints: %w", err)
+		}
+	}
+
+	b.log.InfoObj("backfill completed", "backfill_result", map[string]any{
+		"provider_id": cfg.ID,
+		"range":       rng.String(),
+		"sources":     len(sources),
+		"skipped":     skipped,
+		"failed":      failed,
+		"elapsed_ms":  time.Since(start).Milliseconds(),
+	})
+	if failed > 0 {
+		return fmt.Errorf("%d of %d backfill sources failed for provider %s", failed, len(sources), cfg.ID)
+	}
+	return nil
+}
+
+// dropOutOfRange removes articles published outside rng and reports how many were removed. Undated
+// articles are kept only when their source was selected by date, since nothing else places them in rng.
+func dropOutOfRange(articles []domain.Article, rng providers.BackfillRange, datedSource bool) ([]domain.Article, int) {
+	kept := make([]domain.Article, 0, len(articles))
+	for _, art := range articles {
+		if art.PublishedAt.IsZero() {
+			if datedSource {
+				kept = append(kept, art)
+			}
+			continue
+		}
+		if rng.Contains(art.PublishedAt) {
+			kept = append(kept, art)
+		}
+	}
+	return kept, len(articles) - len(kept)
+}
@@ -0,0 +1,121 @@
+package crawler
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/samvad-hq/samvad-news-harvester/internal/domain"
+	"github.com/samvad-hq/samvad-news-harvester/pkg/providers"
+)
+
+// sourceRecorder returns one article per source URL and can fail a chosen source.
+type sourceRecorder struct {
+	sources []string
+	failOn  string
+}
+
+func (f *sourceRecorder) ID() string { return "recorder" }
+
+func (f *sourceRecorder) Fetch(_ context.Context, cfg providers.Provider) ([]domain.Article, error) {
+	f.sources = append(f.sources, cfg.SourceURL)
+	if cfg.SourceURL == f.failOn {
+		return nil, context.DeadlineExceeded
+	}
+	return []domain.Article{{ID: cfg.SourceURL, URL: cfg.SourceURL}}, nil
+}
+
+// memCheckpoints keeps backfill checkpoints in memory.
+type memCheckpoints map[string]bool
+
+func (m memCheckpoints) BackfillDone(key string) (bool, error) { return m[key], nil }
+func (m memCheckpoints) MarkBackfillDone(key string) error     { m[key] = true; return nil }
+
+func (m memCheckpoints) ClearBackfillDone(prefix string) error {
+	for key := range m {
+		if strings.HasPrefix(key, prefix) {
+			delete(m, key)
+		}
+	}
+	return nil
+}
+
+func TestBackfillResumesFromCheckpoints(t *testing.T) {
+	cfg := providers.Provider{ID: "p1", Name: "P1", Type: "recorder", SourceURL: "https://example.com/{date}.xml"}
+	rng, err := providers.ParseBackfillRange(cfg, "2024-03-01", "2024-03-03")
+	if err != nil {
+		t.Fatalf("ParseBackfillRange: %v", err)
+	}
+
+	fetcher := &sourceRecorder{failOn: "https://example.com/2024-03-02.xml"}
+	checkpoints := memCheckpoints{}
+	pub := &fakePublisher{}
+	backfill := NewBackfill(&fakeRegistry{fetcher: fetcher}, stubHTTPClient{}, pub, nil, &fakeDeduper{}, checkpoints)
+	backfill.processor.scraper = nil
+
+	if err := backfill.Run(context.Background(), cfg, rng); err == nil {
+		t.Fatalf("expected error reporting the failed source")
+	}
+	if len(pub.events) != 2 || len(checkpoints) != 2 {
+		t.Fatalf("expected 2 published and checkpointed sources, got %d events and %d checkpoints", len(pub.events), len(checkpoints))
+	}
+
+	// A rerun over an overlapping range resumes too.
+	rng, err = providers.ParseBackfillRange(cfg, "2024-03-02", "2024-03-03")
+	if err != nil {
+		t.Fatalf("ParseBackfillRange: %v", err)
+	}
+	fetcher.failOn = ""
+	fetcher.sources = nil
+	if err := backfill.Run(context.Background(), cfg, rng); err != nil {
+		t.Fatalf("resumed Run: %v", err)
+	}
+	if want := []string{"https://example.com/2024-03-02.xml"}; !slices.Equal(fetcher.sources, want) {
+		t.Fatalf("resumed run fetched %v, want %v", fetcher.sources, want)
+	}
+	if len(pub.events) != 3 {
+		t.Fatalf("expected the retried source to be published, got %d events", len(pub.events))
+	}
+	if len(checkpoints) != 0 {
+		t.Fatalf("expected checkpoints to be cleared once the backfill completed, got %v", checkpoints)
+	}
+}
+
+func TestBackfillPublishesOnlyArticlesInRange(t *testing.T) {
+	cfg := providers.Provider{ID: "p1", Name: "P1", Type: "fixture", SourceURL: "https://example.com/{date}.xml"}
+	rng, err := providers.ParseBackfillRange(cfg, "2024-03-01", "2024-03-02")
+	if err != nil {
+		t.Fatalf("ParseBackfillRange: %v", err)
+	}
+	articles := []domain.Article{
+		{ID: "before", PublishedAt: time.Date(2024, 2, 29, 23, 59, 0, 0, time.UTC)},
+		{ID: "first", PublishedAt: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{ID: "last", PublishedAt: time.Date(2024, 3, 2, 23, 59, 0, 0, time.UTC)},
+		{ID: "after", PublishedAt: time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC)},
+		{ID: "undated"},
+	}
+
+	pub := &fakePublisher{}
+	backfill := NewBackfill(&fakeRegistry{fetcher: &fakeFetcher{id: "fixture", articles: articles}}, stubHTTPClient{}, pub, nil, &fakeDeduper{}, nil)
+	backfill.processor.scraper = nil
+	if err := backfill.Run(context.Background(), cfg, rng); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	var ids []string
+	for _, evt := range pub.events {
+		ids = append(ids, evt.Article.ID)
+	}
+	slices.Sort(ids)
+	// The sources are rendered per day, so their undated articles are taken to be in range.
+	if want := []string{"first", "last", "undated"}; !slices.Equal(ids, want) {
+		t.Fatalf("published %v, want %v", ids, want)
+	}
+
+	kept, dropped := dropOutOfRange(articles, rng, false)
+	if len(kept) != 2 || dropped != 3 {
+		t.Fatalf("expected undated articles of an undated source to be dropped, kept %+v", kept)
+	}
+}
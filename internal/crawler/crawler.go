@@ -4,17 +4,29 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"sync"
 	"time"
 
 	"github.com/samvad-hq/samvad-news-harvester/internal/domain"
 	"github.com/samvad-hq/samvad-news-harvester/internal/logger"
+	"github.com/samvad-hq/samvad-news-harvester/pkg/httpclient"
 	"github.com/samvad-hq/samvad-news-harvester/pkg/providers"
 	"github.com/samvad-hq/samvad-news-harvester/pkg/publishers"
 )
 
 const maxProviderWorkers = 10
 
+// Reasons reported under articles_dropped when articles are discarded before enrichment.
+const (
+	dropReasonMaxAge      = "max_age"
+	dropReasonMaxArticles = "max_articles"
+	// dropReasonOffSite counts pushed entries linking outside the provider's site.
+	dropReasonOffSite = "off_site"
+	// dropReasonOutOfRange counts backfilled articles published outside the backfill range.
+	dropReasonOutOfRange = "out_of_range"
+)
+
 // Service orchestrates crawling of news providers, article enrichment, and publishing.
 type Service struct {
 	processor *ProviderProcessor
@@ -22,12 +34,13 @@ type Service struct {
 }
 
 // NewService builds a crawler service with the given fetcher registry and event publisher.
-func NewService(reg providers.FetcherRegistry, pub EventPublisher, log logger.Logger, deduper ArticleDeduper) *Service {
+// client is used to scrape article pages; nil selects the default HTTP client.
+func NewService(reg providers.FetcherRegistry, client httpclient.Client, pub EventPublisher, log logger.Logger, deduper ArticleDeduper) *Service {
 	if log == nil {
 		log = logger.NopLogger{}
 	}
 
-	scraper := NewScraper(nil, log)
+	scraper := NewScraper(client, log)
 
 	processor := NewProviderProcessor(reg, scraper, pub, log, deduper)
 	return &Service{
@@ -54,6 +67,14 @@ func (s *Service) Run(ctx context.Context, cfgs []providers.Provider) error {
 	return nil
 }
 
+// ProcessPushed publishes the new articles in feed content a WebSub hub pushed for cfg.
+func (s *Service) ProcessPushed(ctx context.Context, cfg providers.Provider, body []byte) error {
+	if s == nil || s.processor == nil {
+		return fmt.Errorf("crawler service is not initialized")
+	}
+	return s.processor.ProcessPushed(ctx, cfg, body)
+}
+
 // runAll concurrently processes all providers using a pool of workers.
 func (s *Service) runAll(ctx context.Context, cfgs []providers.Provider) []error {
 	workerCount := min(len(cfgs), maxProviderWorkers)
@@ -134,6 +155,15 @@ func NewProviderProcessor(reg providers.FetcherRegistry, scraper ArticleScraper,
 
 // Process fetches, enriches, and publishes articles for the given provider configuration.
 func (p *ProviderProcessor) Process(ctx context.Context, cfg providers.Provider, workerID int) error {
+	return p.process(ctx, cfg, workerID, "", nil)
+}
+
+// articleFilter removes fetched articles before delivery and reports how many it removed.
+type articleFilter func([]domain.Article) ([]domain.Article, int)
+
+// process runs Process, passing fetched articles through filter first when it is set. Articles the filter
+// removes are counted under articles_dropped[reason].
+func (p *ProviderProcessor) process(ctx context.Context, cfg providers.Provider, workerID int, reason string, filter articleFilter) error {
 	if p == nil || p.registry == nil {
 		return fmt.Errorf("provider processor not initialized")
 	}
@@ -145,49 +175,114 @@ func (p *ProviderProcessor) Process(ctx context.Context, cfg providers.Provider,
 	}
 
 	articles, err := fetcher.Fetch(ctx, cfg)
-	if err != nil {
+	partial, degraded := providers.IsPartial(err)
+	if err != nil && !degraded {
 		return fmt.Errorf("fetch provider %s: %w", cfg.ID, err)
 	}
-
-	fetchedCount := len(articles)
-	if p.deduper != nil && fetchedCount > 0 {
-		articles = p.filterNewArticles(cfg, articles)
+	if degraded {
+		p.log.WarnObj("provider crawl degraded", "provider_degraded", map[string]any{
+			"worker_id":      workerID,
+			"provider_id":    cfg.ID,
+			"failed_sources": partial.URLs(),
+			"error":          partial.Error(),
+		})
 	}
 
-	if p.scraper != nil {
-		articles = p.scraper.Enrich(ctx, cfg, articles)
+	var fetchStats map[string]int
+	if reporter, ok := fetcher.(providers.FetchStatsReporter); ok {
+		fetchStats = reporter.LastFetchStats(cfg.ID)
 	}
 
-	if len(articles) == 0 {
-		p.log.InfoObj("provider crawl completed", "provider_result", map[string]any{
-			"worker_id":          workerID,
-			"provider_id":        cfg.ID,
-			"articles_fetched":   fetchedCount,
-			"articles_fresh":     0,
-			"articles_published": 0,
-			"elapsed_ms":         time.Since(start).Milliseconds(),
-		})
-		return nil
+	fetchedCount := len(articles)
+	var filtered int
+	if filter != nil {
+		articles, filtered = filter(articles)
 	}
-
-	published := 0
-	if count, err := p.publishArticles(ctx, cfg, articles); err != nil {
+	fresh, published, dropped, err := p.deliver(ctx, cfg, articles)
+	if err != nil {
 		return fmt.Errorf("publish provider %s articles: %w", cfg.ID, err)
-	} else {
-		published = count
+	}
+	if filter != nil {
+		dropped[reason] = filtered
+	}
+	// Only now are the sources safe to skip when unchanged: committing before delivery would lose the
+	// articles of a failed run to the next crawl's 304. The same goes for articles held back by
+	// max_articles, which the next crawl must fetch again to publish.
+	if committer, ok := fetcher.(providers.ValidatorCommitter); ok && dropped[dropReasonMaxArticles] == 0 {
+		committer.CommitValidators(cfg.ID)
 	}
 
 	p.log.InfoObj("provider crawl completed", "provider_result", map[string]any{
 		"worker_id":          workerID,
 		"provider_id":        cfg.ID,
 		"articles_fetched":   fetchedCount,
-		"articles_fresh":     len(articles),
+		"articles_fresh":     fresh,
 		"articles_published": published,
+		"articles_dropped":   dropped,
+		"degraded":           degraded,
+		"fetch_stats":        fetchStats,
 		"elapsed_ms":         time.Since(start).Milliseconds(),
 	})
 	return nil
 }
 
+// ProcessPushed parses feed content pushed by a WebSub hub for cfg and runs it through the same dedupe,
+// enrich and publish steps as a crawl.
+func (p *ProviderProcessor) ProcessPushed(ctx context.Context, cfg providers.Provider, body []byte) error {
+	if p == nil {
+		return fmt.Errorf("provider processor not initialized")
+	}
+
+	start := time.Now()
+	articles, offSite, err := providers.ParsePushedFeed(cfg, body)
+	if err != nil {
+		return fmt.Errorf("parse push for provider %s: %w", cfg.ID, err)
+	}
+
+	pushedCount := len(articles) + offSite
+	fresh, published, dropped, err := p.deliver(ctx, cfg, articles)
+	if err != nil {
+		return fmt.Errorf("publish provider %s pushed articles: %w", cfg.ID, err)
+	}
+	dropped[dropReasonOffSite] = offSite
+
+	p.log.InfoObj("provider push processed", "push_result", map[string]any{
+		"provider_id":        cfg.ID,
+		"articles_pushed":    pushedCount,
+		"articles_fresh":     fresh,
+		"articles_published": published,
+		"articles_dropped":   dropped,
+		"elapsed_ms":         time.Since(start).Milliseconds(),
+	})
+	return nil
+}
+
+// deliver drops stale and already-published articles, applies max_articles, enriches the rest and
+// publishes them. It returns how many articles were enriched and published, and the drop counts by reason.
+func (p *ProviderProcessor) deliver(ctx context.Context, cfg providers.Provider, articles []domain.Article) (fresh, published int, dropped map[string]int, err error) {
+	dropped = map[string]int{}
+	articles, dropped[dropReasonMaxAge] = dropStale(articles, cfg.MaxAgeDuration(), time.Now())
+	sortNewestFirst(articles)
+	if p.deduper != nil && len(articles) > 0 {
+		articles = p.filterNewArticles(cfg, articles)
+	}
+	// The budget applies after dedupe so already-published articles do not use it up.
+	if limit := cfg.MaxArticles; limit > 0 && len(articles) > limit {
+		dropped[dropReasonMaxArticles] = len(articles) - limit
+		articles = articles[:limit]
+	}
+
+	if p.scraper != nil {
+		articles = p.scraper.Enrich(ctx, cfg, articles)
+	}
+	if len(articles) == 0 {
+		return 0, 0, dropped, nil
+	}
+
+	published, err = p.publishArticles(ctx, cfg, articles)
+	return len(articles), published, dropped, err
+}
+
 // publishArticles publishes the given articles for the provider and returns the count of successfully published articles and any errors.
 func (p *ProviderProcessor) publishArticles(ctx context.Context, cfg providers.Provider, articles []domain.Article) (int, error) {
 	if p.publisher == nil || len(articles) == 0 {
@@ -253,3 +348,36 @@ func (p *ProviderProcessor) filterNewArticles(cfg providers.Provider, articles [
 	}
 	return fresh
 }
+
+// dropStale removes articles published more than maxAge before now and reports how many were removed.
+// Undated articles are kept since their age is unknown; maxAge <= 0 disables the filter.
+func dropStale(articles []domain.Article, maxAge time.Duration, now time.Time) ([]domain.Article, int) {
+	if maxAge <= 0 || len(articles) == 0 {
+		return articles, 0
+	}
+
+	cutoff := now.Add(-maxAge)
+	kept := make([]domain.Article, 0, len(articles))
+	for _, art := range articles {
+		if !art.PublishedAt.IsZero() && art.PublishedAt.Before(cutoff) {
+			continue
+		}
+		kept = append(kept, art)
+	}
+	return kept, len(articles) - len(kept)
+}
+
+// sortNewestFirst orders articles by PublishedAt descending; undated articles follow in their original order.
+func sortNewestFirst(articles []domain.Article) {
+	slices.SortStableFunc(articles, func(a, b domain.Article) int {
+		switch {
+		case a.PublishedAt.IsZero() && b.PublishedAt.IsZero():
+			return 0
+		case a.PublishedAt.IsZero():
+			return 1
+		case b.PublishedAt.IsZero():
+			return -1
+		}
+		return b.PublishedAt.Compare(a.PublishedAt)
+	})
+}
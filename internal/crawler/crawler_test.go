@@ -3,16 +3,18 @@ package crawler
 import (
 	"context"
 	"errors"
+	"slices"
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/samvad-hq/samvad-news-harvester/internal/domain"
 	"github.com/samvad-hq/samvad-news-harvester/pkg/providers"
 	"github.com/samvad-hq/samvad-news-harvester/pkg/publishers"
 )
 
-// fakeFetcher returns preset articles or an error.
+// fakeFetcher returns preset articles and/or an error.
 type fakeFetcher struct {
 	id       string
 	articles []domain.Article
@@ -21,10 +23,7 @@ type fakeFetcher struct {
 
 func (f *fakeFetcher) ID() string { return f.id }
 func (f *fakeFetcher) Fetch(_ context.Context, _ providers.Provider) ([]domain.Article, error) {
-	if f.err != nil {
-		return nil, f.err
-	}
-	return f.articles, nil
+	return f.articles, f.err
 }
 
 // fakeRegistry maps provider type to a single fetcher.
@@ -129,6 +128,56 @@ func TestProviderProcessorPublishesFreshArticlesOnly(t *testing.T) {
 	}
 }
 
+func TestProviderProcessorAppliesMaxAgeAndBudget(t *testing.T) {
+	now := time.Now()
+	cfg := providers.Provider{ID: "p1", Name: "Provider1", MaxAge: "24h", MaxArticles: 2}
+	articles := []domain.Article{
+		{ID: "stale", PublishedAt: now.Add(-48 * time.Hour)},
+		{ID: "undated"},
+		{ID: "older", PublishedAt: now.Add(-3 * time.Hour)},
+		{ID: "seen", PublishedAt: now.Add(-time.Minute)},
+		{ID: "newest", PublishedAt: now.Add(-2 * time.Minute)},
+		{ID: "middle", PublishedAt: now.Add(-time.Hour)},
+	}
+
+	deduper := &fakeDeduper{seen: map[string]bool{"seen": true}}
+	pub := &fakePublisher{}
+	processor := NewProviderProcessor(&fakeRegistry{
+		fetcher: &fakeFetcher{id: "p1", articles: articles},
+	}, nil, pub, nil, deduper)
+
+	if err := processor.Process(context.Background(), cfg, 1); err != nil {
+		t.Fatalf("Process: %v", err)
+	}
+
+	var ids []string
+	for _, evt := range pub.events {
+		ids = append(ids, evt.Article.ID)
+	}
+	if want := []string{"newest", "middle"}; !slices.Equal(ids, want) {
+		t.Fatalf("published %v, want %v", ids, want)
+	}
+}
+
+func TestSortNewestFirstKeepsUndatedLast(t *testing.T) {
+	base := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+	articles := []domain.Article{
+		{ID: "u1"},
+		{ID: "old", PublishedAt: base},
+		{ID: "u2"},
+		{ID: "new", PublishedAt: base.Add(time.Hour)},
+	}
+	sortNewestFirst(articles)
+
+	var ids []string
+	for _, a := range articles {
+		ids = append(ids, a.ID)
+	}
+	if want := []string{"new", "old", "u1", "u2"}; !slices.Equal(ids, want) {
+		t.Fatalf("got %v, want %v", ids, want)
+	}
+}
+
 func TestProviderProcessorAggregatesPublishErrors(t *testing.T) {
 	cfg := providers.Provider{ID: "p1", Name: "Provider1"}
 	pub := &fakePublisher{errOnID: "bad"}
@@ -142,11 +191,89 @@ func TestProviderProcessorAggregatesPublishErrors(t *testing.T) {
 	}
 }
 
+// committingFetcher records which providers had their validators committed.
+type committingFetcher struct {
+	fakeFetcher
+	committed []string
+}
+
+func (f *committingFetcher) CommitValidators(providerID string) {
+	f.committed = append(f.committed, providerID)
+}
+
+func TestProviderProcessorCommitsValidatorsAfterDelivery(t *testing.T) {
+	cfg := providers.Provider{ID: "p1", Name: "Provider1"}
+	fetcher := &committingFetcher{fakeFetcher: fakeFetcher{id: "p1", articles: []domain.Article{{ID: "bad"}}}}
+	processor := NewProviderProcessor(&fakeRegistry{fetcher: fetcher}, nil, &fakePublisher{errOnID: "bad"}, nil, &fakeDeduper{})
+
+	if err := processor.Process(context.Background(), cfg, 0); err == nil {
+		t.Fatalf("expected publish error")
+	}
+	if len(fetcher.committed) != 0 {
+		t.Fatalf("validators must not be committed when publishing fails, got %v", fetcher.committed)
+	}
+
+	fetcher.articles = []domain.Article{{ID: "good"}}
+	if err := processor.Process(context.Background(), cfg, 0); err != nil {
+		t.Fatalf("Process: %v", err)
+	}
+	if !slices.Equal(fetcher.committed, []string{"p1"}) {
+		t.Fatalf("expected validators committed once, got %v", fetcher.committed)
+	}
+}
+
+func TestProviderProcessorSkipsCommitWhenMaxArticlesDrops(t *testing.T) {
+	cfg := providers.Provider{ID: "p1", Name: "Provider1", MaxArticles: 1}
+	fetcher := &committingFetcher{fakeFetcher: fakeFetcher{id: "p1", articles: []domain.Article{{ID: "a1"}, {ID: "a2"}}}}
+	pub := &fakePublisher{}
+	processor := NewProviderProcessor(&fakeRegistry{fetcher: fetcher}, nil, pub, nil, &fakeDeduper{})
+
+	if err := processor.Process(context.Background(), cfg, 0); err != nil {
+		t.Fatalf("Process: %v", err)
+	}
+	if len(pub.events) != 1 || len(fetcher.committed) != 0 {
+		t.Fatalf("expected 1 published and no commit while articles were held back, got %d published, committed %v", len(pub.events), fetcher.committed)
+	}
+
+	// The next crawl delivers the held-back article and the sources can be skipped again.
+	if err := processor.Process(context.Background(), cfg, 0); err != nil {
+		t.Fatalf("Process: %v", err)
+	}
+	if len(pub.events) != 2 || !slices.Equal(fetcher.committed, []string{"p1"}) {
+		t.Fatalf("expected the remaining article published and a commit, got %d published, committed %v", len(pub.events), fetcher.committed)
+	}
+}
+
+func TestProviderProcessorPublishesPartialResults(t *testing.T) {
+	cfg := providers.Provider{ID: "p1", Name: "Provider1"}
+	pub := &fakePublisher{}
+	partial := &providers.PartialError{Failures: []providers.SourceFailure{
+		{URL: "https://example.com/sitemap-sports.xml", Err: errors.New("status 500")},
+	}}
+	processor := NewProviderProcessor(&fakeRegistry{
+		fetcher: &fakeFetcher{id: "p1", articles: []domain.Article{{ID: "a1"}}, err: partial},
+	}, nil, pub, nil, nil)
+
+	if err := processor.Process(context.Background(), cfg, 0); err != nil {
+		t.Fatalf("expected degraded run to succeed, got %v", err)
+	}
+	if len(pub.events) != 1 {
+		t.Fatalf("expected partial articles to be published, got %d events", len(pub.events))
+	}
+
+	processor = NewProviderProcessor(&fakeRegistry{
+		fetcher: &fakeFetcher{id: "p1", err: errors.New("boom")},
+	}, nil, pub, nil, nil)
+	if err := processor.Process(context.Background(), cfg, 0); err == nil {
+		t.Fatalf("expected plain fetch errors to fail the provider")
+	}
+}
+
 func TestServiceRunAllCancelsEarly(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel()
 
-	svc := NewService(&fakeRegistry{fetcher: &fakeFetcher{id: "p", articles: nil}}, nil, nil, nil)
+	svc := NewService(&fakeRegistry{fetcher: &fakeFetcher{id: "p", articles: nil}}, nil, nil, nil, nil)
 	errs := svc.runAll(ctx, []providers.Provider{{ID: "p"}})
 	if len(errs) != 0 {
 		t.Fatalf("expected no errors on cancelled context, got %v", errs)
@@ -154,7 +281,7 @@ func TestServiceRunAllCancelsEarly(t *testing.T) {
 }
 
 func TestRunOnceLogsAndReturnsOnEmptyProviders(t *testing.T) {
-	svc := NewService(&fakeRegistry{fetcher: &fakeFetcher{id: "p", articles: nil}}, nil, nil, nil)
+	svc := NewService(&fakeRegistry{fetcher: &fakeFetcher{id: "p", articles: nil}}, nil, nil, nil, nil)
 	if err := svc.Run(context.Background(), nil); err == nil {
 		t.Fatalf("expected error when providers list empty")
 	}
@@ -178,3 +305,35 @@ func TestFilterNewArticlesHandlesDeduperErrors(t *testing.T) {
 		t.Fatalf("unexpected filter result %#v", filtered)
 	}
 }
+
+func TestProviderProcessorProcessPushedPublishesNewEntries(t *testing.T) {
+	cfg := providers.Provider{ID: "p1", Name: "Provider1", Type: providers.ProviderTypeRSS, SourceURL: "https://feeds.example.com/rss"}
+	body := []byte(`<rss><channel>
+  <item><title>seen</title><link>https://example.com/seen</link></item>
+  <item><title>breaking</title><link>https://example.com/breaking</link></item>
+  <item><title>spoofed</title><link>https://attacker.example.net/story</link></item>
+</channel></rss>`)
+
+	articles, _, err := providers.ParsePushedFeed(cfg, body)
+	if err != nil || len(articles) != 2 {
+		t.Fatalf("ParsePushedFeed: %v %+v", err, articles)
+	}
+
+	deduper := &fakeDeduper{seen: map[string]bool{articles[0].ID: true}}
+	pub := &fakePublisher{}
+	processor := NewProviderProcessor(&fakeRegistry{}, fakeScraper{prefix: "enriched-"}, pub, nil, deduper)
+
+	if err := processor.ProcessPushed(context.Background(), cfg, body); err != nil {
+		t.Fatalf("ProcessPushed: %v", err)
+	}
+	if len(pub.events) != 1 || pub.events[0].Article.Title != "enriched-breaking" {
+		t.Fatalf("expected only the new entry to be published, got %+v", pub.events)
+	}
+	if !deduper.seen[articles[1].ID] {
+		t.Fatalf("MarkArticle not called for pushed article")
+	}
+
+	if err := processor.ProcessPushed(context.Background(), cfg, []byte("not xml")); err == nil {
+		t.Fatalf("expected error for an unparseable push")
+	}
+}
@@ -23,3 +23,10 @@ type ArticleDeduper interface {
 	SeenArticle(id string) (bool, error)
 	MarkArticle(id string) error
 }
+
+// BackfillCheckpoints records which backfill sources have completed so an interrupted backfill can resume.
+type BackfillCheckpoints interface {
+	BackfillDone(key string) (bool, error)
+	MarkBackfillDone(key string) error
+	ClearBackfillDone(prefix string) error
+}
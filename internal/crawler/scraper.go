@@ -3,16 +3,20 @@ package crawler
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/samvad-hq/samvad-news-harvester/internal/domain"
 	"github.com/samvad-hq/samvad-news-harvester/internal/logger"
 	"github.com/samvad-hq/samvad-news-harvester/pkg/httpclient"
 	"github.com/samvad-hq/samvad-news-harvester/pkg/providers"
+	"github.com/samvad-hq/samvad-news-harvester/pkg/robots"
 
 	"github.com/PuerkitoBio/goquery"
 )
@@ -22,6 +26,9 @@ const (
 	maxArticleWorkers = 10
 )
 
+// errNoIndex marks pages whose meta robots or X-Robots-Tag forbid indexing.
+var errNoIndex = errors.New("page is marked noindex")
+
 // Scraper fetches and enriches article metadata by scraping HTML pages.
 type Scraper struct {
 	client httpclient.Client
@@ -40,10 +47,12 @@ func NewScraper(client httpclient.Client, log logger.Logger) *Scraper {
 }
 
 // Enrich enriches the given articles by scraping their HTML pages for metadata.
+// Articles disallowed by robots.txt or marked noindex are dropped from the result.
 func (s *Scraper) Enrich(ctx context.Context, cfg providers.Provider, articles []domain.Article) []domain.Article {
 	delay := cfg.RequestDelay()
 	out := make([]domain.Article, len(articles))
 	copy(out, articles) // default to originals so partial results are returned on cancel
+	skip := make([]bool, len(articles))
 
 	if len(articles) == 0 {
 		return out
@@ -61,10 +70,11 @@ func (s *Scraper) Enrich(ctx context.Context, cfg providers.Provider, articles [
 
 	jobCh := make(chan int)
 	var wg sync.WaitGroup
+	var dateFailures atomic.Int64
 
 	for workerID := range workerCount {
 		wg.Add(1)
-		go s.articleWorker(ctx, cfg, articles, limiter, jobCh, out, &wg, workerID)
+		go s.articleWorker(ctx, cfg, articles, limiter, jobCh, out, skip, &dateFailures, &wg, workerID)
 	}
 
 	for idx := range articles {
@@ -77,7 +87,20 @@ func (s *Scraper) Enrich(ctx context.Context, cfg providers.Provider, articles [
 
 	wg.Wait()
 
-	return out
+	if n := dateFailures.Load(); n > 0 {
+		s.log.WarnObj("article dates not parsed", "date_parse_failures", map[string]any{
+			"provider_id": cfg.ID,
+			"count":       n,
+		})
+	}
+
+	kept := out[:0]
+	for idx, art := range out {
+		if !skip[idx] {
+			kept = append(kept, art)
+		}
+	}
+	return kept
 }
 
 // articleWorker processes articles from the job channel, respecting the rate limiter, and enriches them by scraping metadata.
@@ -88,6 +111,8 @@ func (s *Scraper) articleWorker(
 	limiter <-chan time.Time,
 	jobCh <-chan int,
 	out []domain.Article,
+	skip []bool,
+	dateFailures *atomic.Int64,
 	wg *sync.WaitGroup,
 	workerID int,
 ) {
@@ -107,7 +132,17 @@ func (s *Scraper) articleWorker(
 		}
 
 		art := articles[idx]
-		if enriched, err := s.fetchAndParse(ctx, cfg, art, workerID); err != nil {
+		enriched, err := s.fetchAndParse(ctx, cfg, art, dateFailures, workerID)
+		switch {
+		case errors.Is(err, robots.ErrDisallowed), errors.Is(err, errNoIndex):
+			s.log.InfoObj("article skipped", "article_skip", map[string]any{
+				"worker_id":   workerID,
+				"provider_id": cfg.ID,
+				"url":         art.URL,
+				"reason":      err.Error(),
+			})
+			skip[idx] = true
+		case err != nil:
 			s.log.WarnObj("article metadata scrape failed", "metadata_error", map[string]any{
 				"worker_id":   workerID,
 				"provider_id": cfg.ID,
@@ -115,15 +150,17 @@ func (s *Scraper) articleWorker(
 				"error":       err.Error(),
 			})
 			out[idx] = art
-		} else {
+		default:
 			out[idx] = enriched
 		}
 	}
 }
 
 // fetchAndParse fetches the article HTML and parses metadata to enrich the article.
-func (s *Scraper) fetchAndParse(ctx context.Context, cfg providers.Provider, art domain.Article, workerID int) (domain.Article, error) {
-	headers := providers.Headers(cfg)
+// Pages whose published date cannot be parsed are counted in dateFailures.
+func (s *Scraper) fetchAndParse(ctx context.Context, cfg providers.Provider, art domain.Article, dateFailures *atomic.Int64, workerID int) (domain.Article, error) {
+	// Article pages usually live on other hosts; Authorize only adds credentials for the provider's own.
+	target, headers := providers.Authorize(cfg, art.URL, providers.Headers(cfg))
 
 	s.log.DebugObj("scraping article metadata", "scrape_start", map[string]any{
 		"worker_id":   workerID,
@@ -131,35 +168,53 @@ func (s *Scraper) fetchAndParse(ctx context.Context, cfg providers.Provider, art
 		"url":         art.URL,
 	})
 
-	resp, err := s.client.Get(ctx, art.URL, headers)
+	resp, err := s.client.Do(ctx, httpclient.Request{
+		Method:       http.MethodGet,
+		URL:          target,
+		Headers:      headers,
+		MaxBodyBytes: maxHTMLBodyBytes,
+	})
 	if err != nil {
-		return art, fmt.Errorf("http fetch: %w", err)
+		return art, fmt.Errorf("http fetch: %w", providers.RedactError(cfg, err))
 	}
 
-	if resp.StatusCode() != 200 {
+	if resp.StatusCode() != http.StatusOK {
 		snippet := strings.TrimSpace(string(resp.Body()))
 		if len(snippet) > 1024 {
 			snippet = snippet[:1024]
 		}
 		return art, fmt.Errorf("status %d body: %s", resp.StatusCode(), snippet)
 	}
+	if ct := resp.ContentType(); ct != "" && !isHTMLContentType(ct) {
+		return art, fmt.Errorf("unsupported content type %q", ct)
+	}
 
 	body := resp.Body()
-	if len(body) > maxHTMLBodyBytes {
+	if len(body) > maxHTMLBodyBytes || resp.ContentLength() > maxHTMLBodyBytes {
 		s.log.DebugObj("html body truncated", "truncation", map[string]any{
 			"worker_id":   workerID,
 			"provider_id": cfg.ID,
 			"url":         art.URL,
-			"original":    len(body),
+			"original":    max(int64(len(body)), resp.ContentLength()),
 			"kept":        maxHTMLBodyBytes,
 		})
-		body = body[:maxHTMLBodyBytes]
+		body = body[:min(len(body), maxHTMLBodyBytes)]
+	}
+
+	// Relative image URLs resolve against the page we actually landed on after redirects.
+	pageURL := art.URL
+	if final := resp.URL(); final != "" {
+		pageURL = final
 	}
 
 	meta, err := parseMeta(body)
 	if err != nil {
 		return art, err
 	}
+	directives := robots.ParseDirectives(headers["User-Agent"], append(resp.Header().Values("X-Robots-Tag"), meta.Robots)...)
+	if directives.NoIndex {
+		return art, errNoIndex
+	}
 	updated := art
 	if meta.Title != "" {
 		updated.Title = meta.Title
@@ -168,12 +223,31 @@ func (s *Scraper) fetchAndParse(ctx context.Context, cfg providers.Provider, art
 		updated.Description = meta.Description
 	}
 	if meta.ImageURL != "" {
-		updated.ImageURL = resolveURL(meta.ImageURL, art.URL)
+		updated.ImageURL = resolveURL(meta.ImageURL, pageURL)
+	}
+	if art.PublishedAt.IsZero() && meta.Published != "" {
+		if published, ok := providers.ParseDate(meta.Published, providers.ProviderLocation(cfg)); ok {
+			updated.PublishedAt = published
+		} else {
+			dateFailures.Add(1)
+		}
 	}
 
 	return updated, nil
 }
 
+// isHTMLContentType reports whether the Content-Type header denotes an HTML or XHTML document. Other XML
+// types, such as RSS and Atom feeds, are not article pages.
+func isHTMLContentType(ct string) bool {
+	mediaType, _, _ := strings.Cut(ct, ";")
+	switch strings.ToLower(strings.TrimSpace(mediaType)) {
+	case "text/html", "application/xhtml+xml":
+		return true
+	default:
+		return false
+	}
+}
+
 // parseMeta extracts page metadata from the HTML body.
 func parseMeta(body []byte) (pageMeta, error) {
 	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
@@ -201,6 +275,12 @@ func parseMeta(body []byte) (pageMeta, error) {
 		extract(`meta[name="description"]`),
 	)
 	pm.ImageURL = extract(`meta[property="og:image"]`)
+	pm.Published = firstNonEmpty(
+		extract(`meta[property="article:published_time"]`),
+		extract(`meta[itemprop="datePublished"]`),
+		extract(`meta[name="pubdate"]`),
+	)
+	pm.Robots = extract(`meta[name="robots" i]`)
 
 	return pm, nil
 }
@@ -210,6 +290,8 @@ type pageMeta struct {
 	Title       string
 	Description string
 	ImageURL    string
+	Published   string
+	Robots      string
 }
 
 // firstNonEmpty returns the first non-empty string from the given values.
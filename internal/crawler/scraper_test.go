@@ -3,7 +3,11 @@ package crawler
 import (
 	"bytes"
 	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/samvad-hq/samvad-news-harvester/internal/domain"
 	"github.com/samvad-hq/samvad-news-harvester/pkg/httpclient"
@@ -14,10 +18,15 @@ import (
 type stubHTTPResponse struct {
 	body       []byte
 	statusCode int
+	header     http.Header
 }
 
-func (s stubHTTPResponse) Body() []byte    { return s.body }
-func (s stubHTTPResponse) StatusCode() int { return s.statusCode }
+func (s stubHTTPResponse) Body() []byte         { return s.body }
+func (s stubHTTPResponse) StatusCode() int      { return s.statusCode }
+func (s stubHTTPResponse) Header() http.Header  { return s.header }
+func (s stubHTTPResponse) URL() string          { return "" }
+func (s stubHTTPResponse) ContentType() string  { return s.header.Get("Content-Type") }
+func (s stubHTTPResponse) ContentLength() int64 { return int64(len(s.body)) }
 
 // stubHTTPClient returns a single response.
 type stubHTTPClient struct {
@@ -28,6 +37,14 @@ func (s stubHTTPClient) Get(_ context.Context, _ string, _ map[string]string) (h
 	return s.resp, nil
 }
 
+func (s stubHTTPClient) Do(_ context.Context, _ httpclient.Request) (httpclient.Response, error) {
+	return s.resp, nil
+}
+
+func (s stubHTTPClient) Stream(context.Context, httpclient.Request) (httpclient.StreamResponse, error) {
+	return nil, errors.New("stream not supported by stub")
+}
+
 func TestParseMetaPrefersOGTags(t *testing.T) {
 	html := []byte(`
 <html>
@@ -76,8 +93,100 @@ func TestScraperEnrichesAndLimitsBody(t *testing.T) {
 	}
 }
 
+func TestScraperFillsMissingPublishedDate(t *testing.T) {
+	resp := stubHTTPResponse{
+		body:       []byte(`<html><head><meta property="article:published_time" content="2024-03-10 09:15:00"></head></html>`),
+		statusCode: 200,
+	}
+	scraper := NewScraper(stubHTTPClient{resp: resp}, nil)
+	cfg := providers.Provider{ID: "p1", Config: map[string]any{providers.ConfigTimezoneKey: "Asia/Kolkata"}}
+	failures := new(atomic.Int64)
+
+	art, err := scraper.fetchAndParse(context.Background(), cfg, domain.Article{ID: "a1", URL: "https://example.com/a"}, failures, 0)
+	if err != nil {
+		t.Fatalf("fetchAndParse: %v", err)
+	}
+	if want := time.Date(2024, 3, 10, 3, 45, 0, 0, time.UTC); !art.PublishedAt.Equal(want) {
+		t.Fatalf("PublishedAt = %v, want %v", art.PublishedAt, want)
+	}
+
+	resp.body = []byte(`<html><head><meta itemprop="datePublished" content="yesterday"></head></html>`)
+	scraper = NewScraper(stubHTTPClient{resp: resp}, nil)
+	if _, err := scraper.fetchAndParse(context.Background(), cfg, domain.Article{ID: "a2", URL: "https://example.com/b"}, failures, 0); err != nil {
+		t.Fatalf("fetchAndParse: %v", err)
+	}
+	if failures.Load() != 1 {
+		t.Fatalf("expected 1 date failure, got %d", failures.Load())
+	}
+}
+
 func TestFirstNonEmpty(t *testing.T) {
 	if got := firstNonEmpty("", " ", "foo", "bar"); got != "foo" {
 		t.Fatalf("firstNonEmpty returned %q", got)
 	}
 }
+
+func TestScraperRejectsNonHTMLContent(t *testing.T) {
+	cases := map[string]stubHTTPResponse{
+		"pdf": {
+			body:       []byte("%PDF-1.7"),
+			statusCode: 200,
+			header:     http.Header{"Content-Type": []string{"application/pdf"}},
+		},
+		"feed": {
+			body:       []byte(`<rss><channel><title>Feed</title></channel></rss>`),
+			statusCode: 200,
+			header:     http.Header{"Content-Type": []string{"application/rss+xml; charset=utf-8"}},
+		},
+	}
+	for name, resp := range cases {
+		scraper := NewScraper(stubHTTPClient{resp: resp}, nil)
+		art := domain.Article{ID: "a1", URL: "https://example.com/doc", Title: "orig"}
+
+		if _, err := scraper.fetchAndParse(context.Background(), providers.Provider{ID: "p1"}, art, new(atomic.Int64), 0); err == nil {
+			t.Fatalf("%s: expected error for non-HTML content type", name)
+		}
+	}
+}
+
+func TestIsHTMLContentType(t *testing.T) {
+	for ct, want := range map[string]bool{
+		"text/html":                       true,
+		"Text/HTML; charset=UTF-8":        true,
+		"application/xhtml+xml":           true,
+		"application/atom+xml":            false,
+		"text/xml":                        false,
+		"application/vnd.ms-htmlhelp":     false,
+		"multipart/x-mixed-replace; html": false,
+	} {
+		if got := isHTMLContentType(ct); got != want {
+			t.Fatalf("isHTMLContentType(%q) = %v, want %v", ct, got, want)
+		}
+	}
+}
+
+func TestScraperDropsNoIndexPages(t *testing.T) {
+	cases := map[string]stubHTTPResponse{
+		"meta": {
+			body:       []byte(`<html><head><meta name="robots" content="noindex, follow"><title>Hidden</title></head></html>`),
+			statusCode: 200,
+		},
+		"header": {
+			body:       []byte(`<html><head><title>Hidden</title></head></html>`),
+			statusCode: 200,
+			header:     http.Header{"X-Robots-Tag": []string{"none"}},
+		},
+	}
+
+	for name, resp := range cases {
+		t.Run(name, func(t *testing.T) {
+			scraper := NewScraper(stubHTTPClient{resp: resp}, nil)
+			cfg := providers.Provider{ID: "p1", RequestDelayMs: 1}
+			articles := []domain.Article{{ID: "a1", URL: "https://example.com/hidden"}}
+
+			if enriched := scraper.Enrich(context.Background(), cfg, articles); len(enriched) != 0 {
+				t.Fatalf("expected noindex article to be dropped, got %#v", enriched)
+			}
+		})
+	}
+}
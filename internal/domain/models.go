@@ -13,4 +13,64 @@ type Article struct {
 	ImageURL    string    `json:"image_url"`
 	Keywords    []string  `json:"keywords"`
 	PublishedAt time.Time `json:"published_at"`
+
+	// Optional metadata from Google News sitemaps (news:publication, news:genres, ...).
+	PublicationName string          `json:"publication_name,omitempty"`
+	Language        string          `json:"language,omitempty"`
+	Genres          []string        `json:"genres,omitempty"`
+	StockTickers    []string        `json:"stock_tickers,omitempty"`
+	Access          string          `json:"access,omitempty"`
+	Images          []Image         `json:"images,omitempty"`
+	Alternates      []AlternateLink `json:"alternates,omitempty"`
+
+	// MediaType is MediaTypeVideo for stories from video sitemaps; empty means a regular article.
+	MediaType string  `json:"media_type,omitempty"`
+	Videos    []Video `json:"videos,omitempty"`
+}
+
+// MediaTypeVideo marks articles that represent video stories.
+const MediaTypeVideo = "video"
+
+// Video is the metadata of a video:video sitemap entry. DurationSeconds is zero when the sitemap omits it.
+type Video struct {
+	Title           string    `json:"title"`
+	Description     string    `json:"description,omitempty"`
+	ThumbnailURL    string    `json:"thumbnail_url,omitempty"`
+	ContentURL      string    `json:"content_url,omitempty"`
+	PlayerURL       string    `json:"player_url,omitempty"`
+	DurationSeconds int       `json:"duration_seconds,omitempty"`
+	PublishedAt     time.Time `json:"published_at"`
+	Tags            []string  `json:"tags,omitempty"`
+	Live            bool      `json:"live,omitempty"`
+}
+
+// Image is an article image with its optional title and caption.
+type Image struct {
+	URL     string `json:"url"`
+	Title   string `json:"title,omitempty"`
+	Caption string `json:"caption,omitempty"`
+}
+
+// AlternateLink points to the same article in another language (xhtml:link rel="alternate" hreflang).
+type AlternateLink struct {
+	Language string `json:"language"`
+	URL      string `json:"url"`
+}
+
+// SourceValidators holds the HTTP cache validators last seen for a provider source URL.
+type SourceValidators struct {
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+}
+
+// IsZero reports whether no validators are recorded.
+func (v SourceValidators) IsZero() bool {
+	return v.ETag == "" && v.LastModified == ""
+}
+
+// DiscoveredSitemaps records the news sitemaps found for a sitemap_discovery provider's site root.
+type DiscoveredSitemaps struct {
+	Root     string    `json:"root"`
+	Sitemaps []string  `json:"sitemaps"`
+	Expires  time.Time `json:"expires"`
 }
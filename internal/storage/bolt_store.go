@@ -1,7 +1,9 @@
 package storage
 
 import (
+	"bytes"
 	"encoding/binary"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -9,11 +11,15 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/samvad-hq/samvad-news-harvester/internal/domain"
 	bolt "go.etcd.io/bbolt"
 )
 
 const (
 	articleBucket    = "articles"
+	validatorBucket  = "source_validators"
+	discoveryBucket  = "discovered_sitemaps"
+	backfillBucket   = "backfill_checkpoints"
 	expiryValueBytes = 8
 )
 
@@ -40,8 +46,12 @@ func openBolt(path string, opts Options) (Store, error) {
 		return nil, fmt.Errorf("open bbolt db: %w", err)
 	}
 	if err := db.Update(func(tx *bolt.Tx) error {
-		_, err := tx.CreateBucketIfNotExists([]byte(articleBucket))
-		return err
+		for _, name := range []string{articleBucket, validatorBucket, discoveryBucket, backfillBucket} {
+			if _, err := tx.CreateBucketIfNotExists([]byte(name)); err != nil {
+				return err
+			}
+		}
+		return nil
 	}); err != nil {
 		db.Close()
 		return nil, fmt.Errorf("init bucket: %w", err)
@@ -122,7 +132,176 @@ func (b *boltStore) MarkArticle(id string) error {
 	})
 }
 
-// maybeCleanupExpired removes expired article hashes on a fixed cadence to avoid unbounded growth.
+// storedValidators is the persisted form of a source's cache validators. Expires is refreshed whenever
+// they are saved, which fetchers also do for sources that answered 304, so only validators of sources no
+// longer polled age out.
+type storedValidators struct {
+	domain.SourceValidators
+	Expires int64 `json:"expires"`
+}
+
+// SourceValidators returns the cache validators stored for a source URL (zero value when unknown).
+func (b *boltStore) SourceValidators(url string) (domain.SourceValidators, error) {
+	if b == nil || b.db == nil {
+		return domain.SourceValidators{}, nil
+	}
+
+	var stored storedValidators
+	err := b.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(validatorBucket))
+		if bucket == nil {
+			return fmt.Errorf("validator bucket missing")
+		}
+		raw := bucket.Get([]byte(url))
+		if raw == nil {
+			return nil
+		}
+		if err := json.Unmarshal(raw, &stored); err != nil {
+			return fmt.Errorf("decode validators: %w", err)
+		}
+		return nil
+	})
+	return stored.SourceValidators, err
+}
+
+// SaveSourceValidators stores the cache validators for a source URL.
+func (b *boltStore) SaveSourceValidators(url string, v domain.SourceValidators) error {
+	if b == nil || b.db == nil {
+		return nil
+	}
+
+	now := time.Now()
+	if err := b.maybeCleanupExpired(now); err != nil {
+		return err
+	}
+
+	return b.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(validatorBucket))
+		if bucket == nil {
+			return fmt.Errorf("validator bucket missing")
+		}
+		return b.putValidators(bucket, url, v, now)
+	})
+}
+
+// putValidators writes v for url, expiring one article TTL after now.
+func (b *boltStore) putValidators(bucket *bolt.Bucket, url string, v domain.SourceValidators, now time.Time) error {
+	raw, err := json.Marshal(storedValidators{SourceValidators: v, Expires: now.Add(b.articleTTL).Unix()})
+	if err != nil {
+		return fmt.Errorf("encode validators: %w", err)
+	}
+	return bucket.Put([]byte(url), raw)
+}
+
+// DiscoveredSitemaps returns the sitemaps discovered for a provider (zero value when unknown or expired).
+func (b *boltStore) DiscoveredSitemaps(providerID string) (domain.DiscoveredSitemaps, error) {
+	var d domain.DiscoveredSitemaps
+	if b == nil || b.db == nil {
+		return d, nil
+	}
+
+	err := b.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(discoveryBucket))
+		if bucket == nil {
+			return fmt.Errorf("discovery bucket missing")
+		}
+		raw := bucket.Get([]byte(providerID))
+		if raw == nil {
+			return nil
+		}
+		if err := json.Unmarshal(raw, &d); err != nil {
+			return fmt.Errorf("decode discovered sitemaps: %w", err)
+		}
+		return nil
+	})
+	if err != nil || !d.Expires.After(time.Now()) {
+		return domain.DiscoveredSitemaps{}, err
+	}
+	return d, nil
+}
+
+// SaveDiscoveredSitemaps stores the sitemaps discovered for a provider; a result without sitemaps removes
+// the stored one.
+func (b *boltStore) SaveDiscoveredSitemaps(providerID string, d domain.DiscoveredSitemaps) error {
+	if b == nil || b.db == nil {
+		return nil
+	}
+
+	return b.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(discoveryBucket))
+		if bucket == nil {
+			return fmt.Errorf("discovery bucket missing")
+		}
+		if len(d.Sitemaps) == 0 {
+			return bucket.Delete([]byte(providerID))
+		}
+		raw, err := json.Marshal(d)
+		if err != nil {
+			return fmt.Errorf("encode discovered sitemaps: %w", err)
+		}
+		return bucket.Put([]byte(providerID), raw)
+	})
+}
+
+// BackfillDone reports whether the backfill step identified by key has completed.
+func (b *boltStore) BackfillDone(key string) (bool, error) {
+	if b == nil || b.db == nil {
+		return false, nil
+	}
+
+	var done bool
+	err := b.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(backfillBucket))
+		if bucket == nil {
+			return fmt.Errorf("backfill bucket missing")
+		}
+		done = bucket.Get([]byte(key)) != nil
+		return nil
+	})
+	return done, err
+}
+
+// MarkBackfillDone records the completion time of the backfill step identified by key.
+func (b *boltStore) MarkBackfillDone(key string) error {
+	if b == nil || b.db == nil {
+		return nil
+	}
+
+	buf := make([]byte, expiryValueBytes)
+	binary.BigEndian.PutUint64(buf, uint64(time.Now().Unix()))
+	return b.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(backfillBucket))
+		if bucket == nil {
+			return fmt.Errorf("backfill bucket missing")
+		}
+		return bucket.Put([]byte(key), buf)
+	})
+}
+
+// ClearBackfillDone removes the checkpoints of every backfill step whose key starts with prefix.
+func (b *boltStore) ClearBackfillDone(prefix string) error {
+	if b == nil || b.db == nil {
+		return nil
+	}
+
+	return b.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(backfillBucket))
+		if bucket == nil {
+			return fmt.Errorf("backfill bucket missing")
+		}
+		cursor := bucket.Cursor()
+		for k, _ := cursor.Seek([]byte(prefix)); k != nil && bytes.HasPrefix(k, []byte(prefix)); k, _ = cursor.Next() {
+			if err := cursor.Delete(); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// maybeCleanupExpired removes expired article hashes, source validators, discovered sitemaps and
+// checkpoints of abandoned backfills on a fixed cadence to avoid unbounded growth. Checkpoints expire one
+// article TTL after they were recorded.
 func (b *boltStore) maybeCleanupExpired(now time.Time) error {
 	if b == nil || b.db == nil {
 		return nil
@@ -142,21 +321,16 @@ func (b *boltStore) maybeCleanupExpired(now time.Time) error {
 	}
 
 	err := b.db.Update(func(tx *bolt.Tx) error {
-		bucket := tx.Bucket([]byte(articleBucket))
-		if bucket == nil {
-			return fmt.Errorf("article bucket missing")
+		if err := pruneBucket(tx, articleBucket, now, decodeExpiry); err != nil {
+			return err
 		}
-
-		cursor := bucket.Cursor()
-		for k, v := cursor.First(); k != nil; k, v = cursor.Next() {
-			expiry, ok := decodeExpiry(v)
-			if !ok || !expiry.After(now) {
-				if err := cursor.Delete(); err != nil {
-					return err
-				}
-			}
+		if err := pruneBucket(tx, validatorBucket, now, decodeValidatorExpiry); err != nil {
+			return err
 		}
-		return nil
+		if err := pruneBucket(tx, discoveryBucket, now, decodeDiscoveryExpiry); err != nil {
+			return err
+		}
+		return pruneBucket(tx, backfillBucket, now, b.decodeCheckpointExpiry)
 	})
 	if err == nil {
 		b.lastCleanup.Store(now.Unix())
@@ -164,6 +338,53 @@ func (b *boltStore) maybeCleanupExpired(now time.Time) error {
 	return err
 }
 
+// pruneBucket deletes the entries of the named bucket that expire at or before now, along with entries
+// whose expiry cannot be decoded.
+func pruneBucket(tx *bolt.Tx, name string, now time.Time, expiry func([]byte) (time.Time, bool)) error {
+	bucket := tx.Bucket([]byte(name))
+	if bucket == nil {
+		return fmt.Errorf("%s bucket missing", name)
+	}
+
+	cursor := bucket.Cursor()
+	for k, v := cursor.First(); k != nil; k, v = cursor.Next() {
+		expires, ok := expiry(v)
+		if !ok || !expires.After(now) {
+			if err := cursor.Delete(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// decodeValidatorExpiry decodes the expiry time of stored source validators.
+func decodeValidatorExpiry(value []byte) (time.Time, bool) {
+	var stored storedValidators
+	if err := json.Unmarshal(value, &stored); err != nil || stored.Expires <= 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(stored.Expires, 0), true
+}
+
+// decodeDiscoveryExpiry decodes the expiry time of stored discovered sitemaps.
+func decodeDiscoveryExpiry(value []byte) (time.Time, bool) {
+	var d domain.DiscoveredSitemaps
+	if err := json.Unmarshal(value, &d); err != nil || d.Expires.IsZero() {
+		return time.Time{}, false
+	}
+	return d.Expires, true
+}
+
+// decodeCheckpointExpiry decodes the completion time of a backfill checkpoint and returns when it expires.
+func (b *boltStore) decodeCheckpointExpiry(value []byte) (time.Time, bool) {
+	done, ok := decodeExpiry(value)
+	if !ok {
+		return time.Time{}, false
+	}
+	return done.Add(b.articleTTL), true
+}
+
 // decodeExpiry decodes the expiry time from the stored byte slice.
 func decodeExpiry(value []byte) (time.Time, bool) {
 	if len(value) != expiryValueBytes {
@@ -3,6 +3,8 @@ package storage
 import (
 	"testing"
 	"time"
+
+	"github.com/samvad-hq/samvad-news-harvester/internal/domain"
 )
 
 func TestBoltStoreMarksAndExpiresArticles(t *testing.T) {
@@ -46,6 +48,157 @@ func TestBoltStoreMarksAndExpiresArticles(t *testing.T) {
 	}
 }
 
+func TestBoltStoreSourceValidators(t *testing.T) {
+	storeRaw, err := openBolt(t.TempDir()+"/cache.db", normalizeOptions(Options{}))
+	if err != nil {
+		t.Fatalf("openBolt: %v", err)
+	}
+	defer storeRaw.Close()
+
+	url := "https://example.com/sitemap.xml"
+	if v, err := storeRaw.SourceValidators(url); err != nil || !v.IsZero() {
+		t.Fatalf("expected no validators, got %+v err=%v", v, err)
+	}
+
+	want := domain.SourceValidators{ETag: `"abc"`, LastModified: "Mon, 01 Jan 2024 00:00:00 GMT"}
+	if err := storeRaw.SaveSourceValidators(url, want); err != nil {
+		t.Fatalf("SaveSourceValidators: %v", err)
+	}
+	got, err := storeRaw.SourceValidators(url)
+	if err != nil {
+		t.Fatalf("SourceValidators: %v", err)
+	}
+	if got != want {
+		t.Fatalf("validators = %+v want %+v", got, want)
+	}
+}
+
+func TestBoltStoreExpiresUnusedSourceValidators(t *testing.T) {
+	storeRaw, err := openBolt(t.TempDir()+"/cache.db", Options{ArticleTTL: time.Hour, CleanupInterval: time.Hour})
+	if err != nil {
+		t.Fatalf("openBolt: %v", err)
+	}
+	store := storeRaw.(*boltStore)
+	defer store.Close()
+
+	v := domain.SourceValidators{ETag: `"abc"`}
+	for _, url := range []string{"https://example.com/polled.xml", "https://example.com/gone.xml"} {
+		if err := store.SaveSourceValidators(url, v); err != nil {
+			t.Fatalf("SaveSourceValidators: %v", err)
+		}
+	}
+
+	// Re-saving the validators of a source still polled (as fetchers do after a 304) keeps them past the
+	// cleanup that follows; reading them alone does not.
+	store.articleTTL = 2 * time.Hour
+	if err := store.SaveSourceValidators("https://example.com/polled.xml", v); err != nil {
+		t.Fatalf("SaveSourceValidators: %v", err)
+	}
+	if got, err := store.SourceValidators("https://example.com/gone.xml"); err != nil || got != v {
+		t.Fatalf("SourceValidators = %+v err=%v", got, err)
+	}
+	store.lastCleanup.Store(0)
+	if err := store.maybeCleanupExpired(time.Now().Add(90 * time.Minute)); err != nil {
+		t.Fatalf("maybeCleanupExpired: %v", err)
+	}
+
+	if got, err := store.SourceValidators("https://example.com/polled.xml"); err != nil || got != v {
+		t.Fatalf("expected polled source to keep its validators, got %+v err=%v", got, err)
+	}
+	if got, err := store.SourceValidators("https://example.com/gone.xml"); err != nil || !got.IsZero() {
+		t.Fatalf("expected unused validators to be pruned, got %+v err=%v", got, err)
+	}
+}
+
+func TestBoltStoreDiscoveredSitemaps(t *testing.T) {
+	storeRaw, err := openBolt(t.TempDir()+"/cache.db", normalizeOptions(Options{}))
+	if err != nil {
+		t.Fatalf("openBolt: %v", err)
+	}
+	store := storeRaw.(*boltStore)
+	defer store.Close()
+
+	want := domain.DiscoveredSitemaps{
+		Root:     "https://example.com",
+		Sitemaps: []string{"https://example.com/news-sitemap.xml"},
+		Expires:  time.Now().Add(time.Hour).Truncate(time.Second),
+	}
+	if err := store.SaveDiscoveredSitemaps("disc", want); err != nil {
+		t.Fatalf("SaveDiscoveredSitemaps: %v", err)
+	}
+	got, err := store.DiscoveredSitemaps("disc")
+	if err != nil || got.Root != want.Root || len(got.Sitemaps) != 1 || !got.Expires.Equal(want.Expires) {
+		t.Fatalf("DiscoveredSitemaps = %+v err=%v", got, err)
+	}
+
+	store.lastCleanup.Store(0)
+	if err := store.maybeCleanupExpired(time.Now().Add(2 * time.Hour)); err != nil {
+		t.Fatalf("maybeCleanupExpired: %v", err)
+	}
+	if got, err := store.DiscoveredSitemaps("disc"); err != nil || len(got.Sitemaps) != 0 {
+		t.Fatalf("expected expired discovery to be pruned, got %+v err=%v", got, err)
+	}
+
+	if err := store.SaveDiscoveredSitemaps("disc", want); err != nil {
+		t.Fatalf("SaveDiscoveredSitemaps: %v", err)
+	}
+	if err := store.SaveDiscoveredSitemaps("disc", domain.DiscoveredSitemaps{}); err != nil {
+		t.Fatalf("SaveDiscoveredSitemaps: %v", err)
+	}
+	if got, err := store.DiscoveredSitemaps("disc"); err != nil || len(got.Sitemaps) != 0 {
+		t.Fatalf("expected empty result to remove the entry, got %+v err=%v", got, err)
+	}
+}
+
+func TestBoltStoreBackfillCheckpoints(t *testing.T) {
+	path := t.TempDir() + "/cache.db"
+	storeRaw, err := openBolt(path, normalizeOptions(Options{}))
+	if err != nil {
+		t.Fatalf("openBolt: %v", err)
+	}
+
+	key := "p1|https://example.com/sitemap-2024-03-01.xml"
+	if done, err := storeRaw.BackfillDone(key); err != nil || done {
+		t.Fatalf("expected pending checkpoint, got done=%v err=%v", done, err)
+	}
+	if err := storeRaw.MarkBackfillDone(key); err != nil {
+		t.Fatalf("MarkBackfillDone: %v", err)
+	}
+	storeRaw.Close()
+
+	reopened, err := openBolt(path, normalizeOptions(Options{}))
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer reopened.Close()
+	if done, err := reopened.BackfillDone(key); err != nil || !done {
+		t.Fatalf("expected checkpoint to survive reopen, got done=%v err=%v", done, err)
+	}
+
+	other := "p10|https://example.com/sitemap.xml"
+	if err := reopened.MarkBackfillDone(other); err != nil {
+		t.Fatalf("MarkBackfillDone: %v", err)
+	}
+	if err := reopened.ClearBackfillDone("p1|"); err != nil {
+		t.Fatalf("ClearBackfillDone: %v", err)
+	}
+	if done, err := reopened.BackfillDone(key); err != nil || done {
+		t.Fatalf("expected checkpoint to be cleared, got done=%v err=%v", done, err)
+	}
+	if done, err := reopened.BackfillDone(other); err != nil || !done {
+		t.Fatalf("expected other provider's checkpoint to remain, got done=%v err=%v", done, err)
+	}
+
+	store := reopened.(*boltStore)
+	store.lastCleanup.Store(0)
+	if err := store.maybeCleanupExpired(time.Now().Add(defaultArticleTTL + time.Minute)); err != nil {
+		t.Fatalf("maybeCleanupExpired: %v", err)
+	}
+	if done, err := reopened.BackfillDone(other); err != nil || done {
+		t.Fatalf("expected abandoned checkpoint to expire, got done=%v err=%v", done, err)
+	}
+}
+
 func TestNewStoreSupportsNoop(t *testing.T) {
 	store, err := NewStore("none", "", Options{})
 	if err != nil {
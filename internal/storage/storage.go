@@ -4,15 +4,25 @@ import (
 	"fmt"
 	"strings"
 	"time"
+
+	"github.com/samvad-hq/samvad-news-harvester/internal/domain"
 )
 
 // Package storage provides local DB/cache abstraction.
 
-// Store tracks published article IDs.
+// Store tracks published article IDs, per-source HTTP cache validators, discovered sitemaps and backfill
+// progress.
 type Store interface {
 	Close() error
 	SeenArticle(id string) (bool, error)
 	MarkArticle(id string) error
+	SourceValidators(url string) (domain.SourceValidators, error)
+	SaveSourceValidators(url string, v domain.SourceValidators) error
+	DiscoveredSitemaps(providerID string) (domain.DiscoveredSitemaps, error)
+	SaveDiscoveredSitemaps(providerID string, d domain.DiscoveredSitemaps) error
+	BackfillDone(key string) (bool, error)
+	MarkBackfillDone(key string) error
+	ClearBackfillDone(prefix string) error
 }
 
 // Options controls retention characteristics for concrete store implementations.
@@ -59,3 +69,16 @@ type noopStore struct{}
 func (noopStore) Close() error                     { return nil }
 func (noopStore) SeenArticle(string) (bool, error) { return false, nil }
 func (noopStore) MarkArticle(string) error         { return nil }
+
+func (noopStore) SourceValidators(string) (domain.SourceValidators, error) {
+	return domain.SourceValidators{}, nil
+}
+func (noopStore) SaveSourceValidators(string, domain.SourceValidators) error { return nil }
+
+func (noopStore) DiscoveredSitemaps(string) (domain.DiscoveredSitemaps, error) {
+	return domain.DiscoveredSitemaps{}, nil
+}
+func (noopStore) SaveDiscoveredSitemaps(string, domain.DiscoveredSitemaps) error { return nil }
+func (noopStore) BackfillDone(string) (bool, error)                              { return false, nil }
+func (noopStore) MarkBackfillDone(string) error                                  { return nil }
+func (noopStore) ClearBackfillDone(string) error                                 { return nil }
@@ -0,0 +1,377 @@
+// Package websub subscribes feed providers to the WebSub hubs they advertise and hands pushed content to
+// the crawler, so new stories arrive without waiting for the next poll.
+package websub
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/samvad-hq/samvad-news-harvester/internal/logger"
+	"github.com/samvad-hq/samvad-news-harvester/pkg/httpclient"
+	"github.com/samvad-hq/samvad-news-harvester/pkg/providers"
+)
+
+const (
+	// maxPushBytes caps the size of a content distribution request.
+	maxPushBytes = 16 << 20 // 16 MiB
+	// checkInterval is how often subscriptions are checked for renewal.
+	checkInterval = time.Minute
+	// retryInterval is how long a subscription request may stay unverified, or a failed one waits, before
+	// it is sent again.
+	retryInterval = 15 * time.Minute
+)
+
+// PushHandler processes feed content a hub delivered for a provider.
+type PushHandler interface {
+	ProcessPushed(ctx context.Context, cfg providers.Provider, body []byte) error
+}
+
+// subscription tracks one provider's hub subscription.
+type subscription struct {
+	cfg   providers.Provider
+	hub   string
+	topic string
+	// expires is when the hub's granted lease ends; zero until verified.
+	expires time.Time
+	// next is when the subscription request is sent again: a retry while unverified, a renewal afterwards.
+	next time.Time
+}
+
+// Subscriber subscribes providers to their hubs, answers hub verification requests and forwards content
+// pushes to a PushHandler. It is an http.Handler to be mounted at the callback URL's path.
+type Subscriber struct {
+	client   httpclient.Client
+	handler  PushHandler
+	log      logger.Logger
+	callback string
+	secret   string
+	lease    time.Duration
+	now      func() time.Time
+
+	mu     sync.Mutex
+	subs   map[string]*subscription
+	ctx    context.Context
+	pushes sync.WaitGroup
+}
+
+// NewSubscriber builds a subscriber. callbackURL is the public base URL of the handler; each provider's
+// callback is callbackURL/<provider id>. secret is never sent as is: each subscription hands its hub a key
+// derived from it, and pushes without a valid X-Hub-Signature under that key are ignored; with an empty
+// secret every push is ignored. lease is the subscription lifetime requested from hubs.
+func NewSubscriber(client httpclient.Client, handler PushHandler, log logger.Logger, callbackURL, secret string, lease time.Duration) *Subscriber {
+	if client == nil {
+		client = providers.DefaultHTTPClient()
+	}
+	if log == nil {
+		log = logger.NopLogger{}
+	}
+	return &Subscriber{
+		client:   client,
+		handler:  handler,
+		log:      log,
+		callback: strings.TrimRight(callbackURL, "/"),
+		secret:   secret,
+		lease:    lease,
+		now:      time.Now,
+		subs:     make(map[string]*subscription),
+		ctx:      context.Background(),
+	}
+}
+
+// Run subscribes every push-capable provider whose feed advertises an https hub, then renews leases before
+// they expire and retries unverified subscriptions until ctx is cancelled. Pushes are processed with ctx.
+func (s *Subscriber) Run(ctx context.Context, cfgs []providers.Provider) error {
+	s.mu.Lock()
+	s.ctx = ctx
+	s.mu.Unlock()
+
+	for _, cfg := range cfgs {
+		if ctx.Err() != nil {
+			break
+		}
+		if !providers.SupportsPush(cfg) {
+			continue
+		}
+		feed, err := providers.DiscoverHub(ctx, s.client, cfg)
+		if err != nil {
+			s.log.WarnObj("websub hub discovery failed", "websub_error", map[string]any{
+				"provider_id": cfg.ID,
+				"error":       err.Error(),
+			})
+			continue
+		}
+		hub := feed.Hub
+		if hub == "" {
+			s.log.DebugObj("websub hub not advertised", "websub_meta", map[string]any{"provider_id": cfg.ID})
+			continue
+		}
+		if u, err := url.Parse(hub); err != nil || u.Scheme != "https" {
+			// The subscription secret would travel in the clear.
+			s.log.WarnObj("websub hub skipped", "websub_error", map[string]any{
+				"provider_id": cfg.ID,
+				"hub":         hub,
+				"error":       "hub is not https",
+			})
+			continue
+		}
+
+		// Pushes may link wherever the polled feed does, e.g. feeds.bbci.co.uk entries pointing at bbc.co.uk.
+		sub := &subscription{cfg: providers.PushProvider(cfg, feed.LinkSites), hub: hub, topic: feed.Topic}
+		s.mu.Lock()
+		s.subs[cfg.ID] = sub
+		s.mu.Unlock()
+		s.subscribe(ctx, sub)
+	}
+
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			for _, sub := range s.due() {
+				s.subscribe(ctx, sub)
+			}
+		}
+	}
+}
+
+// Wait blocks until pushes being processed have finished. Call it once the handler stops receiving requests.
+func (s *Subscriber) Wait() {
+	s.pushes.Wait()
+}
+
+// due returns the subscriptions whose retry or renewal time has come.
+func (s *Subscriber) due() []*subscription {
+	now := s.now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var out []*subscription
+	for _, sub := range s.subs {
+		if !now.Before(sub.next) {
+			out = append(out, sub)
+		}
+	}
+	return out
+}
+
+// subscribe sends a subscription request to the hub. The hub confirms it asynchronously through a
+// verification request; until then the request is retried every retryInterval.
+func (s *Subscriber) subscribe(ctx context.Context, sub *subscription) {
+	form := url.Values{}
+	form.Set("hub.mode", "subscribe")
+	form.Set("hub.topic", sub.topic)
+	form.Set("hub.callback", s.callbackFor(sub.cfg.ID))
+	if s.lease > 0 {
+		form.Set("hub.lease_seconds", strconv.Itoa(int(s.lease.Seconds())))
+	}
+	if secret := subscriptionSecret(s.secret, sub.cfg.ID, sub.topic); secret != "" {
+		form.Set("hub.secret", secret)
+	}
+
+	// Schedule the retry before sending: the hub may verify before its response arrives, and verification
+	// reschedules the subscription for renewal.
+	s.mu.Lock()
+	sub.next = s.now().Add(retryInterval)
+	s.mu.Unlock()
+
+	headers := providers.Headers(sub.cfg)
+	headers["Content-Type"] = "application/x-www-form-urlencoded"
+	resp, err := s.client.Do(ctx, httpclient.Request{
+		Method:  http.MethodPost,
+		URL:     sub.hub,
+		Headers: headers,
+		Body:    []byte(form.Encode()),
+	})
+	if err == nil && (resp.StatusCode() < 200 || resp.StatusCode() > 299) {
+		err = fmt.Errorf("hub returned status %d", resp.StatusCode())
+	}
+
+	if err != nil {
+		s.log.WarnObj("websub subscribe failed", "websub_error", map[string]any{
+			"provider_id": sub.cfg.ID,
+			"hub":         sub.hub,
+			"topic":       sub.topic,
+			"error":       err.Error(),
+		})
+		return
+	}
+	s.log.InfoObj("websub subscription requested", "websub_meta", map[string]any{
+		"provider_id": sub.cfg.ID,
+		"hub":         sub.hub,
+		"topic":       sub.topic,
+	})
+}
+
+// callbackFor returns the callback URL for a provider.
+func (s *Subscriber) callbackFor(providerID string) string {
+	return s.callback + "/" + url.PathEscape(providerID)
+}
+
+// ServeHTTP answers hub verification requests (GET) and accepts content distribution requests (POST) on
+// <callback path>/<provider id>.
+func (s *Subscriber) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	providerID, err := url.PathUnescape(r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:])
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		s.verify(w, r, providerID)
+	case http.MethodPost:
+		s.receive(w, r, providerID)
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// verify confirms subscribe requests this subscriber made, and unsubscribe requests for providers it no
+// longer follows, by echoing hub.challenge. Denials are logged and retried later.
+func (s *Subscriber) verify(w http.ResponseWriter, r *http.Request, providerID string) {
+	q := r.URL.Query()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sub := s.subs[providerID]
+
+	switch q.Get("hub.mode") {
+	case "subscribe":
+		if sub == nil || q.Get("hub.topic") != sub.topic {
+			http.NotFound(w, r)
+			return
+		}
+		lease := s.lease
+		if seconds, err := strconv.Atoi(q.Get("hub.lease_seconds")); err == nil && seconds > 0 {
+			lease = time.Duration(seconds) * time.Second
+		}
+		now := s.now()
+		sub.expires = now.Add(lease)
+		// Renew once 90% of the lease has passed.
+		sub.next = now.Add(lease * 9 / 10)
+		s.log.InfoObj("websub subscription verified", "websub_meta", map[string]any{
+			"provider_id":   providerID,
+			"hub":           sub.hub,
+			"lease_seconds": int(lease.Seconds()),
+			"expires_at":    sub.expires.UTC(),
+		})
+	case "unsubscribe":
+		if sub != nil {
+			http.NotFound(w, r)
+			return
+		}
+	case "denied":
+		if sub != nil {
+			sub.next = s.now().Add(retryInterval)
+		}
+		s.log.WarnObj("websub subscription denied", "websub_error", map[string]any{
+			"provider_id": providerID,
+			"topic":       q.Get("hub.topic"),
+			"reason":      q.Get("hub.reason"),
+		})
+		w.WriteHeader(http.StatusOK)
+		return
+	default:
+		http.Error(w, "unsupported hub.mode", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(http.StatusOK)
+	_, _ = io.WriteString(w, q.Get("hub.challenge"))
+}
+
+// receive accepts pushed feed content and processes it in the background. Content that is unsigned or
+// fails signature verification is acknowledged but dropped, as the WebSub spec requires: the callback URL
+// is public, so only the hub holding the subscription's secret may publish through it.
+func (s *Subscriber) receive(w http.ResponseWriter, r *http.Request, providerID string) {
+	s.mu.Lock()
+	sub := s.subs[providerID]
+	ctx := s.ctx
+	s.mu.Unlock()
+	if sub == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxPushBytes+1))
+	if err != nil || len(body) > maxPushBytes {
+		http.Error(w, "unreadable body", http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+
+	if s.secret == "" || !validSignature(subscriptionSecret(s.secret, sub.cfg.ID, sub.topic), r.Header.Get("X-Hub-Signature"), body) {
+		s.log.WarnObj("websub push signature invalid", "websub_error", map[string]any{"provider_id": providerID})
+		return
+	}
+	if s.handler == nil {
+		return
+	}
+
+	s.pushes.Add(1)
+	go func() {
+		defer s.pushes.Done()
+		if err := s.handler.ProcessPushed(ctx, sub.cfg, body); err != nil {
+			s.log.ErrorObj("websub push failed", "websub_error", map[string]any{
+				"provider_id": providerID,
+				"error":       err.Error(),
+			})
+		}
+	}()
+}
+
+// subscriptionSecret derives the hub.secret of one subscription from the configured secret, so a hub only
+// learns a key that signs pushes for the provider and topic it serves.
+func subscriptionSecret(secret, providerID, topic string) string {
+	if secret == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(providerID + "|" + topic))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// validSignature checks an X-Hub-Signature header of the form "<algo>=<hex hmac>" against body.
+func validSignature(secret, header string, body []byte) bool {
+	algo, sig, ok := strings.Cut(strings.TrimSpace(header), "=")
+	if !ok {
+		return false
+	}
+	var newHash func() hash.Hash
+	switch strings.ToLower(algo) {
+	case "sha1":
+		newHash = sha1.New
+	case "sha256":
+		newHash = sha256.New
+	case "sha384":
+		newHash = sha512.New384
+	case "sha512":
+		newHash = sha512.New
+	default:
+		return false
+	}
+	want, err := hex.DecodeString(sig)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(newHash, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), want)
+}
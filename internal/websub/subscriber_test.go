@@ -0,0 +1,298 @@
+package websub
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/samvad-hq/samvad-news-harvester/pkg/httpclient"
+	"github.com/samvad-hq/samvad-news-harvester/pkg/providers"
+)
+
+// fakeResponse is a canned httpclient.Response.
+type fakeResponse struct {
+	status int
+	body   []byte
+}
+
+func (f fakeResponse) Body() []byte         { return f.body }
+func (f fakeResponse) StatusCode() int      { return f.status }
+func (f fakeResponse) Header() http.Header  { return http.Header{} }
+func (f fakeResponse) URL() string          { return "" }
+func (f fakeResponse) ContentType() string  { return "" }
+func (f fakeResponse) ContentLength() int64 { return int64(len(f.body)) }
+
+// fakeClient serves feeds on GET and records hub requests made with Do.
+type fakeClient struct {
+	feeds      map[string][]byte
+	hubStatus  int
+	subscribed chan url.Values
+}
+
+func (f *fakeClient) Get(_ context.Context, u string, _ map[string]string) (httpclient.Response, error) {
+	body, ok := f.feeds[u]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return fakeResponse{status: http.StatusOK, body: body}, nil
+}
+
+func (f *fakeClient) Do(ctx context.Context, req httpclient.Request) (httpclient.Response, error) {
+	if req.Method != http.MethodPost {
+		return f.Get(ctx, req.URL, req.Headers)
+	}
+	form, _ := url.ParseQuery(string(req.Body))
+	f.subscribed <- form
+	return fakeResponse{status: f.hubStatus}, nil
+}
+
+func (f *fakeClient) Stream(context.Context, httpclient.Request) (httpclient.StreamResponse, error) {
+	return nil, errors.New("not supported")
+}
+
+// recordingHandler captures pushes.
+type recordingHandler struct {
+	mu     sync.Mutex
+	pushes []string
+	done   chan struct{}
+}
+
+func (h *recordingHandler) ProcessPushed(_ context.Context, cfg providers.Provider, body []byte) error {
+	h.mu.Lock()
+	h.pushes = append(h.pushes, cfg.ID+":"+string(body))
+	h.mu.Unlock()
+	h.done <- struct{}{}
+	return nil
+}
+
+const testFeed = `<rss xmlns:atom="http://www.w3.org/2005/Atom"><channel>
+  <atom:link rel="hub" href="https://hub.example/"/>
+  <atom:link rel="self" href="https://example.com/feed.xml"/>
+</channel></rss>`
+
+// feedWithHub returns a feed advertising hub, with self as its topic.
+func feedWithHub(hub, self string) []byte {
+	return []byte(`<rss xmlns:atom="http://www.w3.org/2005/Atom"><channel>
+  <atom:link rel="hub" href="` + hub + `"/>
+  <atom:link rel="self" href="` + self + `"/>
+</channel></rss>`)
+}
+
+// sign returns an X-Hub-Signature header for body under key.
+func sign(key, body string) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func startSubscriber(t *testing.T, secret string) (*Subscriber, *fakeClient, *recordingHandler, url.Values) {
+	t.Helper()
+	sub, client, handler, forms := runSubscriber(t, secret, map[string][]byte{"https://example.com/rss": []byte(testFeed)}, []providers.Provider{
+		{ID: "p1", Type: providers.ProviderTypeRSS, SourceURL: "https://example.com/rss"},
+		{ID: "map", Type: providers.ProviderTypeSitemap, SourceURL: "https://example.com/sitemap.xml"},
+	}, 1)
+	return sub, client, handler, forms[0]
+}
+
+// runSubscriber runs a subscriber over cfgs until the test ends and waits for want subscription requests.
+func runSubscriber(t *testing.T, secret string, feeds map[string][]byte, cfgs []providers.Provider, want int) (*Subscriber, *fakeClient, *recordingHandler, []url.Values) {
+	t.Helper()
+	client := &fakeClient{
+		feeds:      feeds,
+		hubStatus:  http.StatusAccepted,
+		subscribed: make(chan url.Values, 4),
+	}
+	handler := &recordingHandler{done: make(chan struct{}, 4)}
+	sub := NewSubscriber(client, handler, nil, "https://harvester.example/websub/", secret, 24*time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		_ = sub.Run(ctx, cfgs)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-stopped
+		sub.Wait()
+	})
+
+	var forms []url.Values
+	for len(forms) < want {
+		select {
+		case form := <-client.subscribed:
+			forms = append(forms, form)
+		case <-time.After(2 * time.Second):
+			t.Fatalf("expected %d subscription requests, got %d", want, len(forms))
+		}
+	}
+	return sub, client, handler, forms
+}
+
+func TestSubscriberSubscribesAndVerifies(t *testing.T) {
+	sub, _, _, form := startSubscriber(t, "s3cret")
+
+	if form.Get("hub.secret") != subscriptionSecret("s3cret", "p1", "https://example.com/feed.xml") || form.Get("hub.mode") != "subscribe" || form.Get("hub.topic") != "https://example.com/feed.xml" ||
+		form.Get("hub.callback") != "https://harvester.example/websub/p1" || form.Get("hub.lease_seconds") != "86400" {
+		t.Fatalf("unexpected subscription request: %v", form)
+	}
+
+	rec := httptest.NewRecorder()
+	sub.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+		"/websub/p1?hub.mode=subscribe&hub.topic=https%3A%2F%2Fexample.com%2Ffeed.xml&hub.challenge=abc123&hub.lease_seconds=3600", nil))
+	if rec.Code != http.StatusOK || rec.Body.String() != "abc123" {
+		t.Fatalf("expected challenge echo, got %d %q", rec.Code, rec.Body.String())
+	}
+
+	sub.mu.Lock()
+	next := sub.subs["p1"].next
+	sub.mu.Unlock()
+	if until := time.Until(next); until < 50*time.Minute || until > 55*time.Minute {
+		t.Fatalf("expected renewal at 90%% of the granted lease, got %v", until)
+	}
+
+	rec = httptest.NewRecorder()
+	sub.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+		"/websub/p1?hub.mode=subscribe&hub.topic=https%3A%2F%2Fother.example%2F&hub.challenge=x", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for a topic we did not request, got %d", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	sub.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+		"/websub/map?hub.mode=subscribe&hub.topic=https%3A%2F%2Fexample.com%2Fsitemap.xml&hub.challenge=x", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for a provider without a subscription, got %d", rec.Code)
+	}
+}
+
+func TestSubscriberRenewsDueSubscriptions(t *testing.T) {
+	sub, _, _, _ := startSubscriber(t, "")
+
+	if due := sub.due(); len(due) != 0 {
+		t.Fatalf("expected nothing due right after subscribing, got %d", len(due))
+	}
+	sub.mu.Lock()
+	sub.now = func() time.Time { return time.Now().Add(retryInterval) }
+	sub.mu.Unlock()
+	if due := sub.due(); len(due) != 1 || due[0].cfg.ID != "p1" {
+		t.Fatalf("expected unverified subscription to be retried, got %+v", due)
+	}
+}
+
+func TestSubscriberHandlesPushes(t *testing.T) {
+	sub, _, handler, _ := startSubscriber(t, "s3cret")
+
+	body := "<rss><channel><item><link>https://example.com/a</link></item></channel></rss>"
+	req := httptest.NewRequest(http.MethodPost, "/websub/p1", strings.NewReader(body))
+	req.Header.Set("X-Hub-Signature", sign(subscriptionSecret("s3cret", "p1", "https://example.com/feed.xml"), body))
+	rec := httptest.NewRecorder()
+	sub.ServeHTTP(rec, req)
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d", rec.Code)
+	}
+	select {
+	case <-handler.done:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("push was not processed")
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/websub/p1", strings.NewReader(body))
+	req.Header.Set("X-Hub-Signature", sign("s3cret", body))
+	rec = httptest.NewRecorder()
+	sub.ServeHTTP(rec, req)
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("expected push signed with the base secret to be acknowledged, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	sub.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/websub/unknown", strings.NewReader(body)))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for unknown provider, got %d", rec.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/websub/p1", strings.NewReader(body))
+	rec = httptest.NewRecorder()
+	sub.ServeHTTP(rec, req)
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("expected unsigned push to be acknowledged, got %d", rec.Code)
+	}
+
+	sub.Wait()
+	handler.mu.Lock()
+	defer handler.mu.Unlock()
+	if len(handler.pushes) != 1 || handler.pushes[0] != "p1:"+body {
+		t.Fatalf("expected exactly the signed push to be processed, got %v", handler.pushes)
+	}
+}
+
+func TestSubscriberWithoutSecretIgnoresPushes(t *testing.T) {
+	sub, _, handler, form := startSubscriber(t, "")
+	if form.Get("hub.secret") != "" {
+		t.Fatalf("unexpected secret in subscription request: %v", form)
+	}
+
+	body := "<rss><channel><item><link>https://example.com/a</link></item></channel></rss>"
+	rec := httptest.NewRecorder()
+	sub.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/websub/p1", strings.NewReader(body)))
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d", rec.Code)
+	}
+	sub.Wait()
+	handler.mu.Lock()
+	defer handler.mu.Unlock()
+	if len(handler.pushes) != 0 {
+		t.Fatalf("expected pushes to be ignored without a secret, got %v", handler.pushes)
+	}
+}
+
+func TestSubscriberScopesSecretsToSubscriptions(t *testing.T) {
+	feeds := map[string][]byte{
+		"https://a.example/rss": feedWithHub("https://hub-a.example/", "https://a.example/feed.xml"),
+		"https://b.example/rss": feedWithHub("https://hub-b.example/", "https://b.example/feed.xml"),
+		"https://c.example/rss": feedWithHub("http://hub-c.example/", "https://c.example/feed.xml"),
+	}
+	sub, client, handler, forms := runSubscriber(t, "s3cret", feeds, []providers.Provider{
+		{ID: "a", Type: providers.ProviderTypeRSS, SourceURL: "https://a.example/rss"},
+		{ID: "b", Type: providers.ProviderTypeRSS, SourceURL: "https://b.example/rss"},
+		{ID: "c", Type: providers.ProviderTypeRSS, SourceURL: "https://c.example/rss"},
+	}, 2)
+
+	secrets := make(map[string]string)
+	for _, form := range forms {
+		secrets[form.Get("hub.topic")] = form.Get("hub.secret")
+	}
+	secretA := secrets["https://a.example/feed.xml"]
+	if secretA == "" || secretA == "s3cret" || secretA == secrets["https://b.example/feed.xml"] {
+		t.Fatalf("expected distinct derived secrets per subscription, got %v", secrets)
+	}
+	select {
+	case form := <-client.subscribed:
+		t.Fatalf("expected no subscription to a plain http hub, got %v", form)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// Hub A signs a push for provider B with the secret it was given.
+	body := "<rss><channel><item><link>https://b.example/forged</link></item></channel></rss>"
+	req := httptest.NewRequest(http.MethodPost, "/websub/b", strings.NewReader(body))
+	req.Header.Set("X-Hub-Signature", sign(secretA, body))
+	rec := httptest.NewRecorder()
+	sub.ServeHTTP(rec, req)
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d", rec.Code)
+	}
+	sub.Wait()
+	handler.mu.Lock()
+	defer handler.mu.Unlock()
+	if len(handler.pushes) != 0 {
+		t.Fatalf("expected push signed with another subscription's secret to be dropped, got %v", handler.pushes)
+	}
+}
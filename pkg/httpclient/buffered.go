@@ -0,0 +1,37 @@
+package httpclient
+
+import "net/http"
+
+// BufferedResponse is a Response backed by an in-memory body.
+type BufferedResponse struct {
+	Status   int
+	Headers  http.Header
+	FinalURL string
+	Data     []byte
+	// Length is the declared content length; -1 when unknown.
+	Length int64
+}
+
+// NewBufferedResponse copies metadata from meta and pairs it with body.
+func NewBufferedResponse(meta Metadata, body []byte) *BufferedResponse {
+	return &BufferedResponse{
+		Status:   meta.StatusCode(),
+		Headers:  meta.Header(),
+		FinalURL: meta.URL(),
+		Data:     body,
+		Length:   meta.ContentLength(),
+	}
+}
+
+func (b *BufferedResponse) Body() []byte         { return b.Data }
+func (b *BufferedResponse) StatusCode() int      { return b.Status }
+func (b *BufferedResponse) Header() http.Header  { return b.Headers }
+func (b *BufferedResponse) URL() string          { return b.FinalURL }
+func (b *BufferedResponse) ContentLength() int64 { return b.Length }
+
+func (b *BufferedResponse) ContentType() string {
+	if b.Headers == nil {
+		return ""
+	}
+	return b.Headers.Get("Content-Type")
+}
@@ -1,14 +1,47 @@
 package httpclient
 
-import "context"
+import (
+	"context"
+	"io"
+	"net/http"
+)
 
-// Response is a minimal HTTP response contract.
+// Metadata describes the parts of an HTTP response available before the body is read.
+type Metadata interface {
+	StatusCode() int
+	Header() http.Header
+	// URL is the effective request URL after redirects.
+	URL() string
+	ContentType() string
+	// ContentLength is the declared body length, or -1 when unknown.
+	ContentLength() int64
+}
+
+// Response is a buffered HTTP response.
 type Response interface {
+	Metadata
 	Body() []byte
-	StatusCode() int
+}
+
+// StreamResponse exposes the response body as a reader; callers must Close it.
+type StreamResponse interface {
+	Metadata
+	io.ReadCloser
+}
+
+// Request describes an HTTP call with an arbitrary verb and optional body.
+type Request struct {
+	Method  string
+	URL     string
+	Headers map[string]string
+	Body    []byte
+	// MaxBodyBytes truncates the buffered response body when positive.
+	MaxBodyBytes int64
 }
 
 // Client abstracts HTTP calls so callers can inject mocks or different transports.
 type Client interface {
 	Get(ctx context.Context, url string, headers map[string]string) (Response, error)
+	Do(ctx context.Context, req Request) (Response, error)
+	Stream(ctx context.Context, req Request) (StreamResponse, error)
 }
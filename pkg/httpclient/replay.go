@@ -0,0 +1,100 @@
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// ReplayClient decorates a Client so that the response streamed for a URL marked with Keep is buffered and
+// served once more to the next GET of that URL, sparing a second download of a document read twice.
+type ReplayClient struct {
+	next Client
+
+	mu   sync.Mutex
+	keep string
+	kept *BufferedResponse
+}
+
+// NewReplayClient wraps next; until Keep is called every request passes straight through.
+func NewReplayClient(next Client) *ReplayClient {
+	return &ReplayClient{next: next}
+}
+
+// Keep marks url so that its next streamed GET response is kept for replay, replacing any response kept
+// earlier. An empty url drops the kept response.
+func (c *ReplayClient) Keep(url string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.keep = url
+	c.kept = nil
+}
+
+// Get performs a GET request, replaying a kept response for url if there is one.
+func (c *ReplayClient) Get(ctx context.Context, url string, headers map[string]string) (Response, error) {
+	return c.Do(ctx, Request{Method: http.MethodGet, URL: url, Headers: headers})
+}
+
+// Do performs the request, replaying a kept response for a GET of the kept URL.
+func (c *ReplayClient) Do(ctx context.Context, req Request) (Response, error) {
+	if kept := c.take(req); kept != nil {
+		return kept, nil
+	}
+	return c.next.Do(ctx, req)
+}
+
+// Stream performs the request, replaying a kept response for a GET of the kept URL. The response of a URL
+// marked with Keep is read in full before it is returned, so it can be served again.
+func (c *ReplayClient) Stream(ctx context.Context, req Request) (StreamResponse, error) {
+	if kept := c.take(req); kept != nil {
+		return replayedStream{BufferedResponse: kept, Reader: bytes.NewReader(kept.Data)}, nil
+	}
+
+	c.mu.Lock()
+	record := c.keep != "" && req.URL == c.keep && isGet(req)
+	c.mu.Unlock()
+
+	resp, err := c.next.Stream(ctx, req)
+	if err != nil || !record {
+		return resp, err
+	}
+	defer resp.Close()
+	body, err := io.ReadAll(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	kept := NewBufferedResponse(resp, body)
+	c.mu.Lock()
+	if c.keep == req.URL {
+		c.kept = kept
+	}
+	c.mu.Unlock()
+	return replayedStream{BufferedResponse: kept, Reader: bytes.NewReader(body)}, nil
+}
+
+// take returns and forgets the kept response when req is a GET of the kept URL.
+func (c *ReplayClient) take(req Request) *BufferedResponse {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.kept == nil || req.URL != c.keep || !isGet(req) {
+		return nil
+	}
+	kept := c.kept
+	c.keep, c.kept = "", nil
+	return kept
+}
+
+func isGet(req Request) bool {
+	return req.Method == "" || req.Method == http.MethodGet
+}
+
+// replayedStream is a StreamResponse over a buffered body.
+type replayedStream struct {
+	*BufferedResponse
+	*bytes.Reader
+}
+
+func (replayedStream) Close() error { return nil }
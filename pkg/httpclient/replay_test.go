@@ -0,0 +1,68 @@
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+)
+
+// countingStreamer streams a fixed body and counts the requests it receives.
+type countingStreamer struct {
+	nopClient
+	calls int
+}
+
+func (c *countingStreamer) Stream(_ context.Context, req Request) (StreamResponse, error) {
+	c.calls++
+	body := []byte("body of " + req.URL)
+	return replayedStream{
+		BufferedResponse: &BufferedResponse{Status: http.StatusOK, FinalURL: req.URL, Data: body, Length: -1},
+		Reader:           bytes.NewReader(body),
+	}, nil
+}
+
+func readStream(t *testing.T, client Client, url string) string {
+	t.Helper()
+	resp, err := client.Stream(context.Background(), Request{Method: http.MethodGet, URL: url})
+	if err != nil {
+		t.Fatalf("Stream: %v", err)
+	}
+	defer resp.Close()
+	body, err := io.ReadAll(resp)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	return string(body)
+}
+
+func TestReplayClientServesKeptResponseOnce(t *testing.T) {
+	next := &countingStreamer{}
+	client := NewReplayClient(next)
+
+	readStream(t, client, "https://example.com/a")
+	client.Keep("https://example.com/b")
+	if got := readStream(t, client, "https://example.com/b"); got != "body of https://example.com/b" {
+		t.Fatalf("unexpected body %q", got)
+	}
+	if got := readStream(t, client, "https://example.com/b"); got != "body of https://example.com/b" {
+		t.Fatalf("unexpected replayed body %q", got)
+	}
+	if next.calls != 2 {
+		t.Fatalf("expected the kept response to be replayed, got %d upstream requests", next.calls)
+	}
+
+	readStream(t, client, "https://example.com/b")
+	if next.calls != 3 {
+		t.Fatalf("expected the response to be replayed only once, got %d upstream requests", next.calls)
+	}
+
+	client.Keep("https://example.com/c")
+	readStream(t, client, "https://example.com/c")
+	client.Keep("")
+	readStream(t, client, "https://example.com/c")
+	if next.calls != 5 {
+		t.Fatalf("expected Keep(\"\") to drop the kept response, got %d upstream requests", next.calls)
+	}
+}
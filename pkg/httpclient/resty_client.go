@@ -2,6 +2,10 @@ package httpclient
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
 	"time"
 
 	"github.com/go-resty/resty/v2"
@@ -31,15 +35,59 @@ func newRestyBaseClient(timeout time.Duration) *resty.Client {
 
 // Get performs an HTTP GET request with the specified context, URL, and headers.
 func (r *RestyClient) Get(ctx context.Context, url string, headers map[string]string) (Response, error) {
-	req := r.client.R().SetContext(ctx)
-	if len(headers) > 0 {
-		req.SetHeaders(headers)
+	return r.Do(ctx, Request{Method: http.MethodGet, URL: url, Headers: headers})
+}
+
+// Do performs an HTTP request with an arbitrary method and body, buffering the response.
+func (r *RestyClient) Do(ctx context.Context, req Request) (Response, error) {
+	if req.MaxBodyBytes <= 0 {
+		resp, err := r.newRequest(ctx, req).Execute(method(req), req.URL)
+		if err != nil {
+			return nil, err
+		}
+		return &restyResponseAdapter{resp: resp}, nil
 	}
-	resp, err := req.Get(url)
+
+	stream, err := r.Stream(ctx, req)
 	if err != nil {
 		return nil, err
 	}
-	return &restyResponseAdapter{resp: resp}, nil
+	defer stream.Close()
+
+	body, err := io.ReadAll(io.LimitReader(stream, req.MaxBodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("read response body: %w", err)
+	}
+	return NewBufferedResponse(stream, body), nil
+}
+
+// Stream performs an HTTP request and returns the unread response body.
+func (r *RestyClient) Stream(ctx context.Context, req Request) (StreamResponse, error) {
+	resp, err := r.newRequest(ctx, req).SetDoNotParseResponse(true).Execute(method(req), req.URL)
+	if err != nil {
+		return nil, err
+	}
+	return &restyStreamAdapter{restyResponseAdapter: restyResponseAdapter{resp: resp}, body: resp.RawBody()}, nil
+}
+
+// newRequest builds a resty request carrying the context, headers and body.
+func (r *RestyClient) newRequest(ctx context.Context, req Request) *resty.Request {
+	rr := r.client.R().SetContext(ctx)
+	if len(req.Headers) > 0 {
+		rr.SetHeaders(req.Headers)
+	}
+	if req.Body != nil {
+		rr.SetBody(req.Body)
+	}
+	return rr
+}
+
+// method returns the request verb, defaulting to GET.
+func method(req Request) string {
+	if m := strings.ToUpper(strings.TrimSpace(req.Method)); m != "" {
+		return m
+	}
+	return http.MethodGet
 }
 
 // restyResponseAdapter adapts resty.Response to the httpclient.Response interface.
@@ -47,5 +95,46 @@ type restyResponseAdapter struct {
 	resp *resty.Response
 }
 
-func (r *restyResponseAdapter) Body() []byte    { return r.resp.Body() }
-func (r *restyResponseAdapter) StatusCode() int { return r.resp.StatusCode() }
+func (r *restyResponseAdapter) Body() []byte        { return r.resp.Body() }
+func (r *restyResponseAdapter) StatusCode() int     { return r.resp.StatusCode() }
+func (r *restyResponseAdapter) Header() http.Header { return r.resp.Header() }
+func (r *restyResponseAdapter) ContentType() string { return r.resp.Header().Get("Content-Type") }
+
+// URL returns the final request URL after redirects.
+func (r *restyResponseAdapter) URL() string {
+	if raw := r.resp.RawResponse; raw != nil && raw.Request != nil && raw.Request.URL != nil {
+		return raw.Request.URL.String()
+	}
+	if r.resp.Request != nil {
+		return r.resp.Request.URL
+	}
+	return ""
+}
+
+// ContentLength returns the declared body length, or -1 when unknown.
+func (r *restyResponseAdapter) ContentLength() int64 {
+	if raw := r.resp.RawResponse; raw != nil {
+		return raw.ContentLength
+	}
+	return -1
+}
+
+// restyStreamAdapter exposes an unparsed resty response as a StreamResponse.
+type restyStreamAdapter struct {
+	restyResponseAdapter
+	body io.ReadCloser
+}
+
+func (r *restyStreamAdapter) Read(p []byte) (int, error) {
+	if r.body == nil {
+		return 0, io.EOF
+	}
+	return r.body.Read(p)
+}
+
+func (r *restyStreamAdapter) Close() error {
+	if r.body == nil {
+		return nil
+	}
+	return r.body.Close()
+}
@@ -0,0 +1,80 @@
+package httpclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRestyClientExposesMetadataAfterRedirect(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/old", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/new", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/new", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte("<html>hello</html>"))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	resp, err := NewRestyClient(2*time.Second).Get(context.Background(), srv.URL+"/old", nil)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if resp.URL() != srv.URL+"/new" {
+		t.Errorf("URL = %s", resp.URL())
+	}
+	if !strings.HasPrefix(resp.ContentType(), "text/html") {
+		t.Errorf("ContentType = %s", resp.ContentType())
+	}
+	if resp.Header().Get("ETag") != `"v1"` {
+		t.Errorf("ETag header = %s", resp.Header().Get("ETag"))
+	}
+	if resp.ContentLength() != int64(len("<html>hello</html>")) {
+		t.Errorf("ContentLength = %d", resp.ContentLength())
+	}
+}
+
+func TestRestyClientDoWithBodyAndLimit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+		payload, _ := io.ReadAll(r.Body)
+		_, _ = w.Write([]byte("echo:" + string(payload)))
+	}))
+	defer srv.Close()
+
+	client := NewRestyClient(2 * time.Second)
+	resp, err := client.Do(context.Background(), Request{
+		Method:       http.MethodPost,
+		URL:          srv.URL,
+		Body:         []byte("abcdef"),
+		MaxBodyBytes: 7,
+	})
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	if string(resp.Body()) != "echo:ab" {
+		t.Fatalf("expected truncated body, got %q", resp.Body())
+	}
+
+	stream, err := client.Stream(context.Background(), Request{Method: http.MethodPost, URL: srv.URL, Body: []byte("xyz")})
+	if err != nil {
+		t.Fatalf("Stream: %v", err)
+	}
+	defer stream.Close()
+	got, err := io.ReadAll(stream)
+	if err != nil {
+		t.Fatalf("read stream: %v", err)
+	}
+	if string(got) != "echo:xyz" || stream.StatusCode() != http.StatusOK {
+		t.Fatalf("unexpected stream result %q status %d", got, stream.StatusCode())
+	}
+}
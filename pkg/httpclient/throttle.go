@@ -0,0 +1,72 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ThrottledClient decorates a Client so that consecutive requests start at least delay apart.
+type ThrottledClient struct {
+	next  Client
+	delay time.Duration
+
+	mu       sync.Mutex
+	nextSlot time.Time
+}
+
+// NewThrottledClient wraps next, spacing requests by delay; a non-positive delay disables throttling.
+func NewThrottledClient(next Client, delay time.Duration) *ThrottledClient {
+	return &ThrottledClient{next: next, delay: delay}
+}
+
+// Get performs a GET request once the next request slot arrives.
+func (c *ThrottledClient) Get(ctx context.Context, url string, headers map[string]string) (Response, error) {
+	return c.Do(ctx, Request{Method: http.MethodGet, URL: url, Headers: headers})
+}
+
+// Do performs the request once the next request slot arrives.
+func (c *ThrottledClient) Do(ctx context.Context, req Request) (Response, error) {
+	if err := c.wait(ctx); err != nil {
+		return nil, err
+	}
+	return c.next.Do(ctx, req)
+}
+
+// Stream performs the request once the next request slot arrives and returns the unread body.
+func (c *ThrottledClient) Stream(ctx context.Context, req Request) (StreamResponse, error) {
+	if err := c.wait(ctx); err != nil {
+		return nil, err
+	}
+	return c.next.Stream(ctx, req)
+}
+
+// wait reserves the next request slot and sleeps until it arrives.
+func (c *ThrottledClient) wait(ctx context.Context) error {
+	if c.delay <= 0 {
+		return nil
+	}
+
+	c.mu.Lock()
+	now := time.Now()
+	slot := c.nextSlot
+	if slot.Before(now) {
+		slot = now
+	}
+	c.nextSlot = slot.Add(c.delay)
+	c.mu.Unlock()
+
+	wait := time.Until(slot)
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
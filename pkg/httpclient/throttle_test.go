@@ -0,0 +1,45 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// nopClient answers every request with an empty 200 response.
+type nopClient struct{}
+
+func (nopClient) Get(context.Context, string, map[string]string) (Response, error) {
+	return &BufferedResponse{Status: 200}, nil
+}
+
+func (nopClient) Do(context.Context, Request) (Response, error) {
+	return &BufferedResponse{Status: 200}, nil
+}
+
+func (nopClient) Stream(context.Context, Request) (StreamResponse, error) {
+	return nil, errors.New("not supported")
+}
+
+func TestThrottledClientSpacesRequests(t *testing.T) {
+	client := NewThrottledClient(nopClient{}, 20*time.Millisecond)
+
+	start := time.Now()
+	for range 3 {
+		if _, err := client.Get(context.Background(), "https://example.com", nil); err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Fatalf("expected three requests to take at least 40ms, took %v", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	slow := NewThrottledClient(nopClient{}, time.Hour)
+	_, _ = slow.Get(ctx, "https://example.com", nil)
+	if _, err := slow.Get(ctx, "https://example.com", nil); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected cancellation while waiting, got %v", err)
+	}
+}
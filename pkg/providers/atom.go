@@ -0,0 +1,63 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/samvad-hq/samvad-news-harvester/internal/domain"
+)
+
+// atomFetcher implements Fetcher for Atom feed providers.
+type atomFetcher struct {
+	client HTTPClient
+	validatorCache
+	fetchStats
+}
+
+// NewAtomFetcher builds a Fetcher for Atom feed providers.
+// validators is optional; when set, unchanged sources are skipped via conditional GETs.
+func NewAtomFetcher(client HTTPClient, validators ValidatorStore) Fetcher {
+	if client == nil {
+		client = DefaultHTTPClient()
+	}
+	return &atomFetcher{client: client, validatorCache: validatorCache{store: validators}}
+}
+
+// ID returns the provider type for the Atom fetcher.
+func (f *atomFetcher) ID() string {
+	return ProviderTypeAtom
+}
+
+// Fetch retrieves articles from an Atom feed provider.
+func (f *atomFetcher) Fetch(ctx context.Context, cfg Provider) ([]domain.Article, error) {
+	if !strings.EqualFold(cfg.Type, ProviderTypeAtom) {
+		return nil, fmt.Errorf("atom fetcher received incompatible provider type %q", cfg.Type)
+	}
+	if strings.TrimSpace(cfg.SourceURL) == "" {
+		return nil, fmt.Errorf("provider %q source_url is empty", cfg.ID)
+	}
+
+	doc, err := fetchDocument(ctx, f.client, cfg.SourceURL, cfg, "feed", ResponseFormatXML, conditionalHeaders(f.store, cfg.SourceURL, Headers(cfg)))
+	if err != nil {
+		return nil, err
+	}
+	if doc.NotModified {
+		f.holdSource(cfg.ID, cfg.SourceURL, doc.Validators)
+		return nil, nil
+	}
+
+	entries, err := parseAtomFeed(doc.Body)
+	if err != nil {
+		return nil, fmt.Errorf("decode atom feed: %w", err)
+	}
+
+	dates := newDateParser(cfg)
+	articles := buildArticlesFromAtom(cfg.ID, entries, dates)
+	f.record(cfg.ID, map[string]int{FetchStatDateParseFailures: dates.failures})
+	if len(articles) == 0 {
+		return nil, fmt.Errorf("%s feed returned no records", cfg.ID)
+	}
+	f.holdSource(cfg.ID, cfg.SourceURL, doc.Validators)
+	return articles, nil
+}
@@ -0,0 +1,205 @@
+package providers
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Supported auth types.
+const (
+	AuthTypeBasic  = "basic"
+	AuthTypeBearer = "bearer"
+	AuthTypeAPIKey = "api_key"
+
+	// AuthInHeader and AuthInQuery select where an api_key credential is sent.
+	AuthInHeader = "header"
+	AuthInQuery  = "query"
+
+	defaultAPIKeyHeader = "X-API-Key"
+	defaultAPIKeyParam  = "api_key"
+)
+
+// Auth describes the credentials sent with a provider's requests. They are only sent to the hosts of the
+// provider's source URLs and to Hosts, never to article pages, hubs or sitemaps elsewhere. Values may
+// reference environment variables as ${NAME}.
+type Auth struct {
+	Type     string `json:"type" yaml:"type"`
+	Username string `json:"username" yaml:"username"`
+	Password string `json:"password" yaml:"password"`
+	Token    string `json:"token" yaml:"token"`
+	// Name is the api_key header or query parameter; defaults to X-API-Key or api_key.
+	Name  string `json:"name" yaml:"name"`
+	Value string `json:"value" yaml:"value"`
+	// In is "header" (default) or "query" for api_key auth.
+	In string `json:"in" yaml:"in"`
+	// Hosts lists additional hosts that receive the credentials.
+	Hosts []string `json:"hosts" yaml:"hosts,omitempty"`
+}
+
+// envPlaceholder matches ${NAME} references.
+var envPlaceholder = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// expandEnv replaces ${NAME} references with the environment value (empty when unset). Lone $ signs are kept.
+func expandEnv(s string) string {
+	return envPlaceholder.ReplaceAllStringFunc(s, func(ref string) string {
+		return os.Getenv(envPlaceholder.FindStringSubmatch(ref)[1])
+	})
+}
+
+// sanitizeAuth trims and env-expands auth fields, returning nil when no auth type is set.
+func sanitizeAuth(a *Auth) *Auth {
+	if a == nil {
+		return nil
+	}
+	out := Auth{
+		Type:     strings.ToLower(strings.TrimSpace(a.Type)),
+		Username: strings.TrimSpace(expandEnv(a.Username)),
+		Password: expandEnv(a.Password),
+		Token:    strings.TrimSpace(expandEnv(a.Token)),
+		Name:     strings.TrimSpace(a.Name),
+		Value:    strings.TrimSpace(expandEnv(a.Value)),
+		In:       strings.ToLower(strings.TrimSpace(a.In)),
+	}
+	for _, host := range trimmedValues(a.Hosts) {
+		out.Hosts = append(out.Hosts, strings.ToLower(host))
+	}
+	if out.Type == "" {
+		return nil
+	}
+	if out.Type == AuthTypeAPIKey {
+		if out.In == "" {
+			out.In = AuthInHeader
+		}
+		if out.Name == "" && out.In == AuthInQuery {
+			out.Name = defaultAPIKeyParam
+		} else if out.Name == "" {
+			out.Name = defaultAPIKeyHeader
+		}
+	}
+	return &out
+}
+
+// validate checks that the credentials required by the auth type are present.
+func (a *Auth) validate() error {
+	if a == nil {
+		return nil
+	}
+	switch a.Type {
+	case AuthTypeBasic:
+		if a.Username == "" {
+			return fmt.Errorf("basic auth requires username")
+		}
+	case AuthTypeBearer:
+		if a.Token == "" {
+			return fmt.Errorf("bearer auth requires token")
+		}
+	case AuthTypeAPIKey:
+		if a.Value == "" {
+			return fmt.Errorf("api_key auth requires value")
+		}
+		if a.In != AuthInHeader && a.In != AuthInQuery {
+			return fmt.Errorf("api_key auth in %q is not supported (expected header or query)", a.In)
+		}
+	default:
+		return fmt.Errorf("auth type %q is not supported (expected basic, bearer or api_key)", a.Type)
+	}
+	return nil
+}
+
+// applyHeaders adds the auth header, if any, to headers.
+func (a *Auth) applyHeaders(headers map[string]string) {
+	if a == nil {
+		return
+	}
+	switch a.Type {
+	case AuthTypeBasic:
+		headers["Authorization"] = "Basic " + base64.StdEncoding.EncodeToString([]byte(a.Username+":"+a.Password))
+	case AuthTypeBearer:
+		headers["Authorization"] = "Bearer " + a.Token
+	case AuthTypeAPIKey:
+		if a.In == AuthInHeader {
+			headers[a.Name] = a.Value
+		}
+	}
+}
+
+// Authorize returns the URL and headers to request raw with. The provider's credentials are added only
+// when raw's host is the host of one of its source URLs or listed in auth.hosts; headers is copied, not
+// modified.
+func Authorize(cfg Provider, raw string, headers map[string]string) (string, map[string]string) {
+	a := cfg.Auth
+	if a == nil || !cfg.authAllowed(raw) {
+		return raw, headers
+	}
+
+	out := make(map[string]string, len(headers)+1)
+	for k, v := range headers {
+		out[k] = v
+	}
+	a.applyHeaders(out)
+
+	if a.Type != AuthTypeAPIKey || a.In != AuthInQuery {
+		return raw, out
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return raw, out
+	}
+	q := u.Query()
+	q.Set(a.Name, a.Value)
+	u.RawQuery = q.Encode()
+	return u.String(), out
+}
+
+// authAllowed reports whether raw points at a host that may receive the provider's credentials.
+func (p Provider) authAllowed(raw string) bool {
+	host := urlHost(raw)
+	if host == "" {
+		return false
+	}
+	for _, src := range append([]string{p.SourceURL}, p.SourceURLs...) {
+		if urlHost(src) == host {
+			return true
+		}
+	}
+	return p.Auth != nil && slices.Contains(p.Auth.Hosts, host)
+}
+
+// urlHost returns the lowercased host name of raw, or "" when it has none.
+func urlHost(raw string) string {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(u.Hostname())
+}
+
+// RedactError masks the provider's query-string API key in err's message, so request URLs quoted by the
+// HTTP client or robots checks do not leak it into logs. errors.Is and errors.As still see err.
+func RedactError(cfg Provider, err error) error {
+	a := cfg.Auth
+	if err == nil || a == nil || a.Type != AuthTypeAPIKey || a.In != AuthInQuery || a.Value == "" {
+		return err
+	}
+	msg := err.Error()
+	redacted := strings.ReplaceAll(msg, url.QueryEscape(a.Value), "REDACTED")
+	redacted = strings.ReplaceAll(redacted, a.Value, "REDACTED")
+	if redacted == msg {
+		return err
+	}
+	return &redactedError{msg: redacted, err: err}
+}
+
+// redactedError carries a redacted message for a wrapped error.
+type redactedError struct {
+	msg string
+	err error
+}
+
+func (e *redactedError) Error() string { return e.msg }
+func (e *redactedError) Unwrap() error { return e.err }
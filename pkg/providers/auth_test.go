@@ -0,0 +1,150 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestLoadRegistryExpandsHeadersAndAuth(t *testing.T) {
+	t.Setenv("PARTNER_TOKEN", "s3cret")
+	t.Setenv("PARTNER_COOKIE", "session=abc")
+
+	path := writeTempFile(t, t.TempDir(), "providers.yaml", `
+providers:
+  - id: partner
+    name: Partner
+    type: json_api
+    source_url: https://api.example.com/v1/stories
+    response_format: json
+    headers:
+      referer: https://www.example.com/
+      Cookie: ${PARTNER_COOKIE}
+    auth:
+      type: Bearer
+      token: ${PARTNER_TOKEN}
+    config:
+      user_agent: ua
+`)
+	reg, err := LoadRegistry(path)
+	if err != nil {
+		t.Fatalf("LoadRegistry: %v", err)
+	}
+	cfg, _ := reg.ByID("partner")
+
+	_, headers := Authorize(cfg, cfg.SourceURL, Headers(cfg))
+	want := map[string]string{
+		"User-Agent":    "ua",
+		"Referer":       "https://www.example.com/",
+		"Cookie":        "session=abc",
+		"Authorization": "Bearer s3cret",
+	}
+	for k, v := range want {
+		if headers[k] != v {
+			t.Errorf("header %s = %q, want %q", k, headers[k], v)
+		}
+	}
+}
+
+func TestAuthHeadersAndQuery(t *testing.T) {
+	basic := Provider{SourceURL: "https://example.com/feed", Auth: sanitizeAuth(&Auth{Type: "basic", Username: "user", Password: "pass"})}
+	if _, headers := Authorize(basic, basic.SourceURL, Headers(basic)); headers["Authorization"] != "Basic dXNlcjpwYXNz" {
+		t.Errorf("basic Authorization = %q", headers["Authorization"])
+	}
+
+	header := Provider{SourceURL: "https://example.com/feed", Auth: sanitizeAuth(&Auth{Type: "api_key", Value: "k1"})}
+	target, headers := Authorize(header, "https://example.com/feed", Headers(header))
+	if headers["X-API-Key"] != "k1" {
+		t.Errorf("api key header = %q", headers["X-API-Key"])
+	}
+	if target != "https://example.com/feed" {
+		t.Errorf("header api key must not touch the URL, got %q", target)
+	}
+
+	query := Provider{SourceURL: "https://example.com/feed", Auth: sanitizeAuth(&Auth{Type: "api_key", In: "query", Value: "k 2"})}
+	target, headers = Authorize(query, "https://example.com/feed?page=1", Headers(query))
+	if target != "https://example.com/feed?api_key=k+2&page=1" {
+		t.Errorf("Authorize URL = %q", target)
+	}
+	if _, ok := headers["X-API-Key"]; ok {
+		t.Errorf("query api key must not be sent as a header")
+	}
+}
+
+func TestAuthorizeOnlyTrustedHosts(t *testing.T) {
+	cfg := Provider{
+		SourceURL:  "https://api.example.com/v1/{date}/stories",
+		SourceURLs: []string{"https://feeds.example.com/rss"},
+		Auth:       sanitizeAuth(&Auth{Type: "bearer", Token: "t", Hosts: []string{" CDN.example.com "}}),
+	}
+	for _, raw := range []string{"https://api.example.com/v1/other", "https://feeds.example.com:443/x", "https://cdn.example.com/sitemap.xml"} {
+		if _, headers := Authorize(cfg, raw, nil); headers["Authorization"] != "Bearer t" {
+			t.Errorf("expected credentials for %s", raw)
+		}
+	}
+	for _, raw := range []string{"https://publisher.example.org/story", "https://hub.example/", "not a url"} {
+		base := map[string]string{"User-Agent": "ua"}
+		if _, headers := Authorize(cfg, raw, base); len(headers) != 1 {
+			t.Errorf("expected no credentials for %s, got %v", raw, headers)
+		}
+	}
+
+	query := Provider{SourceURL: "https://api.example.com/feed", Auth: sanitizeAuth(&Auth{Type: "api_key", In: "query", Value: "k1"})}
+	if target, _ := Authorize(query, "https://publisher.example.org/story", nil); target != "https://publisher.example.org/story" {
+		t.Errorf("query api key leaked to %q", target)
+	}
+}
+
+func TestRedactError(t *testing.T) {
+	cfg := Provider{Auth: sanitizeAuth(&Auth{Type: "api_key", In: "query", Value: "s3cr/t"})}
+	cause := errors.New("disallowed by robots.txt")
+	err := RedactError(cfg, fmt.Errorf("https://example.com/feed?api_key=s3cr%%2Ft: %w", cause))
+	if strings.Contains(err.Error(), "s3cr") || !strings.Contains(err.Error(), "api_key=REDACTED") {
+		t.Fatalf("expected redacted message, got %q", err)
+	}
+	if !errors.Is(err, cause) {
+		t.Fatalf("redacted error must keep the wrapped chain")
+	}
+	if plain := errors.New("boom"); RedactError(cfg, plain) != plain {
+		t.Fatalf("errors without the key should be returned unchanged")
+	}
+}
+
+func TestAuthValidation(t *testing.T) {
+	cases := map[string]*Auth{
+		"missing token":   {Type: "bearer", Token: "${UNSET_TOKEN_FOR_TEST}"},
+		"missing user":    {Type: "basic", Password: "p"},
+		"unknown type":    {Type: "digest"},
+		"bad placement":   {Type: "api_key", Value: "v", In: "cookie"},
+		"missing api key": {Type: "api_key"},
+	}
+	for name, a := range cases {
+		if err := sanitizeAuth(a).validate(); err == nil {
+			t.Errorf("%s: expected validation error", name)
+		}
+	}
+	if err := sanitizeAuth(&Auth{}).validate(); err != nil {
+		t.Errorf("empty auth block should be ignored, got %v", err)
+	}
+}
+
+func TestFetcherSendsQueryAPIKey(t *testing.T) {
+	client := &fakeHTTPClient{responses: map[string]fakeResponse{
+		"https://example.com/feed.xml?key=abc": {
+			body:       []byte(`<rss><channel><item><link>https://example.com/a</link></item></channel></rss>`),
+			statusCode: http.StatusOK,
+		},
+	}}
+	cfg := Provider{
+		ID:        "p1",
+		Type:      ProviderTypeRSS,
+		SourceURL: "https://example.com/feed.xml",
+		Auth:      sanitizeAuth(&Auth{Type: "api_key", In: "query", Name: "key", Value: "abc"}),
+	}
+	if _, err := NewRSSFetcher(client, nil).Fetch(context.Background(), cfg); err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+}
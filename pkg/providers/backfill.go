@@ -0,0 +1,164 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/samvad-hq/samvad-news-harvester/pkg/httpclient"
+)
+
+// backfillDateLayout is the format of backfill range bounds.
+const backfillDateLayout = "2006-01-02"
+
+// BackfillRange is an inclusive range of calendar days in a provider's timezone.
+type BackfillRange struct {
+	From time.Time
+	To   time.Time
+}
+
+// ParseBackfillRange parses YYYY-MM-DD bounds as midnight in the provider's timezone.
+func ParseBackfillRange(cfg Provider, from, to string) (BackfillRange, error) {
+	loc := ProviderLocation(cfg)
+	start, err := time.ParseInLocation(backfillDateLayout, strings.TrimSpace(from), loc)
+	if err != nil {
+		return BackfillRange{}, fmt.Errorf("invalid backfill start %q (expected YYYY-MM-DD)", from)
+	}
+	end, err := time.ParseInLocation(backfillDateLayout, strings.TrimSpace(to), loc)
+	if err != nil {
+		return BackfillRange{}, fmt.Errorf("invalid backfill end %q (expected YYYY-MM-DD)", to)
+	}
+	if end.Before(start) {
+		return BackfillRange{}, fmt.Errorf("backfill end %s is before start %s", to, from)
+	}
+	return BackfillRange{From: start, To: end}, nil
+}
+
+// String renders the range as "from..to".
+func (r BackfillRange) String() string {
+	return r.From.Format(backfillDateLayout) + ".." + r.To.Format(backfillDateLayout)
+}
+
+// Contains reports whether t falls on a day within the range.
+func (r BackfillRange) Contains(t time.Time) bool {
+	return !t.Before(r.From) && t.Before(r.To.AddDate(0, 0, 1))
+}
+
+// BackfillSource is an archive source planned for a backfill.
+type BackfillSource struct {
+	URL string
+	// Dated reports that the source was picked for a day in the range, as a rendered source_url template
+	// or an index child whose <lastmod> falls in it, so its undated articles can be taken to be in range.
+	Dated bool
+}
+
+// BackfillSources lists the archive sources covering the range, oldest first. Providers with dated
+// source_url templates or source_urls are rendered for every day; sitemap providers are expanded into the
+// children of their sitemap index whose <lastmod> falls in the range (undated children are kept). When the
+// provider's sitemap is not an index and client is an httpclient.ReplayClient, the sitemap read while
+// planning is kept for the fetch that follows instead of being downloaded again.
+func BackfillSources(ctx context.Context, client HTTPClient, cfg Provider, rng BackfillRange) ([]BackfillSource, error) {
+	if cfg.hasMultipleSources() {
+		seen := make(map[string]struct{})
+		var out []BackfillSource
+		for day := rng.From; !day.After(rng.To); day = day.AddDate(0, 0, 1) {
+			sources, err := cfg.Sources(day)
+			if err != nil {
+				return nil, fmt.Errorf("provider %q: %w", cfg.ID, err)
+			}
+			for _, source := range sources {
+				if _, dup := seen[source]; dup {
+					continue
+				}
+				seen[source] = struct{}{}
+				out = append(out, BackfillSource{URL: source, Dated: true})
+			}
+		}
+		return out, nil
+	}
+
+	switch cfg.Type {
+	case ProviderTypeGoogleNews, ProviderTypeSitemap, ProviderTypeVideoSitemap:
+	default:
+		return nil, fmt.Errorf("provider %q of type %q has no dated sources or sitemap index to backfill", cfg.ID, cfg.Type)
+	}
+
+	replay, _ := client.(*httpclient.ReplayClient)
+	if replay != nil {
+		target, _ := Authorize(cfg, cfg.SourceURL, nil)
+		replay.Keep(target)
+	}
+	doc, err := streamSitemapDocument[sitemapURL](ctx, client, cfg.SourceURL, cfg, Headers(cfg), defaultSitemapMaxEntries)
+	if err != nil {
+		return nil, err
+	}
+	if doc.Kind != sitemapKindIndex {
+		return []BackfillSource{{URL: cfg.SourceURL}}, nil
+	}
+	if replay != nil {
+		replay.Keep("")
+	}
+
+	loc := ProviderLocation(cfg)
+	var dated []sitemapIndexEntry
+	var undated []string
+	for _, child := range doc.Sitemaps {
+		lastMod, ok := ParseDate(child.LastMod, loc)
+		switch {
+		case !ok:
+			undated = append(undated, child.Loc)
+		case rng.Contains(lastMod.In(loc)):
+			dated = append(dated, child)
+		}
+	}
+	slices.SortStableFunc(dated, func(a, b sitemapIndexEntry) int {
+		lastA, _ := ParseDate(a.LastMod, loc)
+		lastB, _ := ParseDate(b.LastMod, loc)
+		return lastA.Compare(lastB)
+	})
+
+	out := make([]BackfillSource, 0, len(dated)+len(undated))
+	for _, child := range dated {
+		out = append(out, BackfillSource{URL: child.Loc, Dated: true})
+	}
+	for _, loc := range undated {
+		out = append(out, BackfillSource{URL: loc})
+	}
+	return out, nil
+}
+
+// BackfillProvider returns a single-source copy of cfg for fetching source during a backfill started at
+// now: freshness windows are widened to reach back to the range start, index children are walked one at a
+// time without a fan-out cap, and max_articles no longer applies.
+func BackfillProvider(cfg Provider, source string, rng BackfillRange, now time.Time) Provider {
+	window := (now.Sub(rng.From) + 24*time.Hour).Round(time.Hour).String()
+
+	out := cfg
+	out.SourceURL = source
+	out.SourceURLs = nil
+	out.MaxAge = window
+	out.MaxArticles = 0
+	out.Config = maps.Clone(cfg.Config)
+	if out.Config == nil {
+		out.Config = make(map[string]any, 4)
+	}
+	out.Config[ConfigSitemapChildWindowKey] = window
+	out.Config[ConfigLastmodWindowKey] = window
+	out.Config[ConfigSitemapMaxChildrenKey] = defaultSitemapMaxEntries
+	out.Config[ConfigSitemapConcurrencyKey] = 1
+	return out
+}
+
+// BackfillCheckpointKey identifies a completed backfill source of a provider. It does not depend on the
+// range, so a backfill over an overlapping range skips the sources an interrupted one already finished.
+func BackfillCheckpointKey(cfg Provider, source string) string {
+	return BackfillCheckpointPrefix(cfg) + source
+}
+
+// BackfillCheckpointPrefix is the common prefix of a provider's backfill checkpoint keys.
+func BackfillCheckpointPrefix(cfg Provider) string {
+	return cfg.ID + "|"
+}
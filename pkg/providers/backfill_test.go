@@ -0,0 +1,130 @@
+package providers
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/samvad-hq/samvad-news-harvester/pkg/httpclient"
+)
+
+func TestBackfillSourcesRendersEveryDay(t *testing.T) {
+	cfg := Provider{
+		ID:         "p1",
+		Type:       ProviderTypeGoogleNews,
+		SourceURL:  "https://example.com/sitemap/{date}.xml",
+		SourceURLs: []string{"https://example.com/sitemap/{date-1}.xml"},
+	}
+	rng, err := ParseBackfillRange(cfg, "2024-03-01", "2024-03-03")
+	if err != nil {
+		t.Fatalf("ParseBackfillRange: %v", err)
+	}
+
+	got, err := BackfillSources(context.Background(), &fakeHTTPClient{}, cfg, rng)
+	if err != nil {
+		t.Fatalf("BackfillSources: %v", err)
+	}
+	want := []BackfillSource{
+		{URL: "https://example.com/sitemap/2024-03-01.xml", Dated: true},
+		{URL: "https://example.com/sitemap/2024-02-29.xml", Dated: true},
+		{URL: "https://example.com/sitemap/2024-03-02.xml", Dated: true},
+		{URL: "https://example.com/sitemap/2024-03-03.xml", Dated: true},
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestBackfillSourcesSelectsIndexChildrenInRange(t *testing.T) {
+	index := `<sitemapindex>
+  <sitemap><loc>https://example.com/2024-03-02.xml</loc><lastmod>2024-03-02T23:00:00+05:30</lastmod></sitemap>
+  <sitemap><loc>https://example.com/2024-02-20.xml</loc><lastmod>2024-02-20</lastmod></sitemap>
+  <sitemap><loc>https://example.com/2024-03-01.xml</loc><lastmod>2024-03-01</lastmod></sitemap>
+  <sitemap><loc>https://example.com/misc.xml</loc></sitemap>
+  <sitemap><loc>https://example.com/2024-03-04.xml</loc><lastmod>2024-03-04T00:30:00+05:30</lastmod></sitemap>
+</sitemapindex>`
+	client := &fakeHTTPClient{responses: map[string]fakeResponse{
+		"https://example.com/index.xml": {body: []byte(index), statusCode: http.StatusOK},
+	}}
+	cfg := Provider{
+		ID:        "p1",
+		Type:      ProviderTypeGoogleNews,
+		SourceURL: "https://example.com/index.xml",
+		Config:    map[string]any{ConfigTimezoneKey: "Asia/Kolkata"},
+	}
+	rng, err := ParseBackfillRange(cfg, "2024-03-01", "2024-03-03")
+	if err != nil {
+		t.Fatalf("ParseBackfillRange: %v", err)
+	}
+
+	got, err := BackfillSources(context.Background(), client, cfg, rng)
+	if err != nil {
+		t.Fatalf("BackfillSources: %v", err)
+	}
+	want := []BackfillSource{
+		{URL: "https://example.com/2024-03-01.xml", Dated: true},
+		{URL: "https://example.com/2024-03-02.xml", Dated: true},
+		{URL: "https://example.com/misc.xml"},
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	if _, err := BackfillSources(context.Background(), client, Provider{ID: "p2", Type: ProviderTypeRSS, SourceURL: "https://example.com/feed"}, rng); err == nil {
+		t.Fatalf("expected error for feed provider without dated sources")
+	}
+}
+
+func TestBackfillReusesPlanningFetchOfURLSet(t *testing.T) {
+	today := time.Now().UTC().Format(backfillDateLayout)
+	urlset := `<urlset><url><loc>https://example.com/a</loc><lastmod>` + today + `</lastmod></url></urlset>`
+	upstream := &fakeHTTPClient{responses: map[string]fakeResponse{
+		"https://example.com/sitemap.xml": {body: []byte(urlset), statusCode: http.StatusOK},
+	}}
+	client := httpclient.NewReplayClient(upstream)
+	cfg := Provider{ID: "p1", Type: ProviderTypeSitemap, SourceURL: "https://example.com/sitemap.xml"}
+	rng, err := ParseBackfillRange(cfg, today, today)
+	if err != nil {
+		t.Fatalf("ParseBackfillRange: %v", err)
+	}
+
+	sources, err := BackfillSources(context.Background(), client, cfg, rng)
+	if err != nil || !slices.Equal(sources, []BackfillSource{{URL: cfg.SourceURL}}) {
+		t.Fatalf("BackfillSources = %v err=%v", sources, err)
+	}
+	articles, err := NewSitemapFetcher(client, nil).Fetch(context.Background(), BackfillProvider(cfg, sources[0].URL, rng, time.Now()))
+	if err != nil || len(articles) != 1 {
+		t.Fatalf("Fetch = %d articles err=%v", len(articles), err)
+	}
+	if len(upstream.calls) != 1 {
+		t.Fatalf("expected the sitemap to be downloaded once, got %v", upstream.calls)
+	}
+}
+
+func TestBackfillProviderWidensWindows(t *testing.T) {
+	cfg := Provider{
+		ID:          "p1",
+		SourceURL:   "https://example.com/{date}.xml",
+		MaxAge:      "24h",
+		MaxArticles: 10,
+		Config:      map[string]any{ConfigUserAgentKey: "ua"},
+	}
+	rng := BackfillRange{From: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)}
+	now := time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC)
+
+	got := BackfillProvider(cfg, "https://example.com/2024-03-01.xml", rng, now)
+	if got.SourceURL != "https://example.com/2024-03-01.xml" || got.hasMultipleSources() {
+		t.Fatalf("expected a single static source, got %+v", got)
+	}
+	if got.MaxAgeDuration() != 11*24*time.Hour || got.MaxArticles != 0 {
+		t.Fatalf("unexpected limits max_age=%v max_articles=%d", got.MaxAgeDuration(), got.MaxArticles)
+	}
+	if ConfigDuration(got, ConfigSitemapChildWindowKey, 0) != 11*24*time.Hour || ConfigInt(got, ConfigSitemapConcurrencyKey, 0) != 1 {
+		t.Fatalf("unexpected sitemap overrides %v", got.Config)
+	}
+	if _, leaked := cfg.Config[ConfigSitemapChildWindowKey]; leaked {
+		t.Fatalf("original config must not be modified")
+	}
+}
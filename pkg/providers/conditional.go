@@ -0,0 +1,105 @@
+package providers
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/samvad-hq/samvad-news-harvester/internal/domain"
+)
+
+// conditionalHeaders copies headers and adds If-None-Match/If-Modified-Since from the stored validators for url.
+func conditionalHeaders(store ValidatorStore, url string, headers map[string]string) map[string]string {
+	out := make(map[string]string, len(headers)+2)
+	for k, v := range headers {
+		out[k] = v
+	}
+	if store == nil {
+		return out
+	}
+
+	v, err := store.SourceValidators(url)
+	if err != nil || v.IsZero() {
+		return out
+	}
+	if v.ETag != "" {
+		out["If-None-Match"] = v.ETag
+	}
+	if v.LastModified != "" {
+		out["If-Modified-Since"] = v.LastModified
+	}
+	return out
+}
+
+// requestValidators returns the validators a conditional request was sent with: the stored ones, which a
+// 304 response confirms are still current.
+func requestValidators(headers map[string]string) domain.SourceValidators {
+	return domain.SourceValidators{ETag: headers["If-None-Match"], LastModified: headers["If-Modified-Since"]}
+}
+
+// validatorCache gives a fetcher its optional ValidatorStore. Validators seen during a provider's run are
+// held instead of saved, and only reach the store through CommitValidators once the caller has delivered
+// the run's articles: saving them earlier would turn the next crawl into a 304 for articles that were
+// never published. Embed it in fetchers; a nil store disables conditional GETs.
+type validatorCache struct {
+	store ValidatorStore
+
+	mu      sync.Mutex
+	pending map[string]map[string]domain.SourceValidators
+}
+
+// validatorHolder is implemented by fetchers embedding validatorCache, letting wrappers that run them once
+// per source gather the validators of every source.
+type validatorHolder interface {
+	hold(providerID string, sources map[string]domain.SourceValidators)
+	takePending(providerID string) map[string]domain.SourceValidators
+}
+
+// hold replaces the validators kept for providerID's most recent run with those of sources.
+func (c *validatorCache) hold(providerID string, sources map[string]domain.SourceValidators) {
+	if c.store == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.pending == nil {
+		c.pending = make(map[string]map[string]domain.SourceValidators)
+	}
+	c.pending[providerID] = sources
+}
+
+// holdSource replaces the validators kept for providerID with those of a single source url.
+func (c *validatorCache) holdSource(providerID, url string, v domain.SourceValidators) {
+	c.hold(providerID, map[string]domain.SourceValidators{url: v})
+}
+
+// takePending removes and returns the validators held for providerID.
+func (c *validatorCache) takePending(providerID string) map[string]domain.SourceValidators {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	sources := c.pending[providerID]
+	delete(c.pending, providerID)
+	return sources
+}
+
+// CommitValidators saves the validators held from providerID's most recent run, re-saving those of sources
+// that answered 304 so the store keeps them for as long as they are polled. Failures are ignored: the next
+// crawl simply refetches in full.
+func (c *validatorCache) CommitValidators(providerID string) {
+	for url, v := range c.takePending(providerID) {
+		if c.store != nil && !v.IsZero() {
+			_ = c.store.SaveSourceValidators(url, v)
+		}
+	}
+}
+
+// responseValidators extracts cache validators from response headers.
+func responseValidators(h http.Header) domain.SourceValidators {
+	if h == nil {
+		return domain.SourceValidators{}
+	}
+	return domain.SourceValidators{
+		ETag:         strings.TrimSpace(h.Get("ETag")),
+		LastModified: strings.TrimSpace(h.Get("Last-Modified")),
+	}
+}
@@ -0,0 +1,144 @@
+package providers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/samvad-hq/samvad-news-harvester/internal/domain"
+)
+
+// memoryValidatorStore keeps validators in a map.
+type memoryValidatorStore map[string]domain.SourceValidators
+
+func (m memoryValidatorStore) SourceValidators(url string) (domain.SourceValidators, error) {
+	return m[url], nil
+}
+
+func (m memoryValidatorStore) SaveSourceValidators(url string, v domain.SourceValidators) error {
+	m[url] = v
+	return nil
+}
+
+func TestGoogleNewsFetcherConditionalGet(t *testing.T) {
+	indexXML := []byte(`<sitemapindex><sitemap><loc>https://example.com/leaf.xml</loc></sitemap></sitemapindex>`)
+	leafXML := []byte(`<urlset><url><loc>https://example.com/a</loc></url></urlset>`)
+
+	client := &fakeHTTPClient{
+		responses: map[string]fakeResponse{
+			"https://example.com/index.xml": {
+				body:       indexXML,
+				statusCode: http.StatusOK,
+				header:     http.Header{"Etag": []string{`"idx"`}},
+			},
+			"https://example.com/leaf.xml": {
+				body:       leafXML,
+				statusCode: http.StatusOK,
+				header:     http.Header{"Etag": []string{`"v1"`}, "Last-Modified": []string{"Mon, 01 Jan 2024 00:00:00 GMT"}},
+			},
+		},
+	}
+	store := memoryValidatorStore{}
+	fetcher := NewGoogleNewsFetcher(client, store)
+	cfg := Provider{ID: "p1", Type: ProviderTypeGoogleNews, SourceURL: "https://example.com/index.xml"}
+
+	articles, err := fetcher.Fetch(context.Background(), cfg)
+	if err != nil || len(articles) != 1 {
+		t.Fatalf("first Fetch: articles=%d err=%v", len(articles), err)
+	}
+	if len(store) != 0 {
+		t.Fatalf("validators must not be saved before they are committed, got %v", store)
+	}
+	fetcher.(ValidatorCommitter).CommitValidators(cfg.ID)
+	if _, ok := store["https://example.com/index.xml"]; ok {
+		t.Fatalf("index validators must not be cached")
+	}
+	if got := store["https://example.com/leaf.xml"].ETag; got != `"v1"` {
+		t.Fatalf("leaf ETag = %q", got)
+	}
+
+	client.responses["https://example.com/leaf.xml"] = fakeResponse{statusCode: http.StatusNotModified}
+	articles, err = fetcher.Fetch(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("expected 304 to be treated as no new articles, got %v", err)
+	}
+	if len(articles) != 0 {
+		t.Fatalf("expected no articles, got %d", len(articles))
+	}
+
+	sent := client.headers["https://example.com/leaf.xml"]
+	if sent["If-None-Match"] != `"v1"` || sent["If-Modified-Since"] != "Mon, 01 Jan 2024 00:00:00 GMT" {
+		t.Fatalf("conditional headers not sent: %#v", sent)
+	}
+	if _, ok := client.headers["https://example.com/index.xml"]["If-None-Match"]; ok {
+		t.Fatalf("index request must not be conditional")
+	}
+
+	// Committing after a 304 saves the confirmed validators again, refreshing their retention.
+	delete(store, "https://example.com/leaf.xml")
+	fetcher.(ValidatorCommitter).CommitValidators(cfg.ID)
+	if got := store["https://example.com/leaf.xml"]; got.ETag != `"v1"` || got.LastModified != "Mon, 01 Jan 2024 00:00:00 GMT" {
+		t.Fatalf("expected unchanged leaf validators to be re-saved, got %+v", got)
+	}
+}
+
+func TestRSSFetcherNotModified(t *testing.T) {
+	client := &fakeHTTPClient{
+		responses: map[string]fakeResponse{
+			"https://example.com/rss": {statusCode: http.StatusNotModified},
+		},
+	}
+	store := memoryValidatorStore{"https://example.com/rss": {ETag: `"x"`}}
+	fetcher := NewRSSFetcher(client, store)
+
+	articles, err := fetcher.Fetch(context.Background(), Provider{
+		ID:        "p1",
+		Type:      ProviderTypeRSS,
+		SourceURL: "https://example.com/rss",
+		Config:    map[string]any{ConfigUserAgentKey: "test-agent"},
+	})
+	if err != nil || len(articles) != 0 {
+		t.Fatalf("expected empty result on 304, got %d err=%v", len(articles), err)
+	}
+
+	sent := client.headers["https://example.com/rss"]
+	if sent["If-None-Match"] != `"x"` || sent["User-Agent"] != "test-agent" {
+		t.Fatalf("unexpected request headers %#v", sent)
+	}
+
+	delete(store, "https://example.com/rss")
+	fetcher.(ValidatorCommitter).CommitValidators("p1")
+	if got := store["https://example.com/rss"]; got.ETag != `"x"` {
+		t.Fatalf("expected the validators confirmed by the 304 to be re-saved, got %+v", got)
+	}
+}
+
+func TestMultiSourceFetcherHoldsValidatorsOfEverySource(t *testing.T) {
+	feed := []byte(`<rss><channel><item><link>https://example.com/a</link></item></channel></rss>`)
+	client := &fakeHTTPClient{responses: map[string]fakeResponse{
+		"https://example.com/one.xml": {body: feed, statusCode: http.StatusOK, header: http.Header{"Etag": []string{`"one"`}}},
+		"https://example.com/two.xml": {body: feed, statusCode: http.StatusOK, header: http.Header{"Etag": []string{`"two"`}}},
+	}}
+	store := memoryValidatorStore{}
+	cfg := Provider{
+		ID:         "p1",
+		Type:       ProviderTypeRSS,
+		SourceURL:  "https://example.com/one.xml",
+		SourceURLs: []string{"https://example.com/two.xml"},
+	}
+	fetcher, err := NewTypeFetcherRegistry(map[string]Fetcher{ProviderTypeRSS: NewRSSFetcher(client, store)}).FetcherFor(cfg)
+	if err != nil {
+		t.Fatalf("FetcherFor: %v", err)
+	}
+
+	if _, err := fetcher.Fetch(context.Background(), cfg); err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if len(store) != 0 {
+		t.Fatalf("validators must not be saved before they are committed, got %v", store)
+	}
+	fetcher.(ValidatorCommitter).CommitValidators(cfg.ID)
+	if store["https://example.com/one.xml"].ETag != `"one"` || store["https://example.com/two.xml"].ETag != `"two"` {
+		t.Fatalf("expected validators for both sources, got %v", store)
+	}
+}
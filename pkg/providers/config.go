@@ -1,6 +1,10 @@
 package providers
 
-import "strings"
+import (
+	"strconv"
+	"strings"
+	"time"
+)
 
 // ConfigString returns the trimmed string value for key from provider.Config or a fallback.
 func ConfigString(cfg Provider, key, fallback string) string {
@@ -23,9 +27,10 @@ const (
 	ConfigCacheControlKey   = "cache_control"
 )
 
-// Headers builds the common request headers from a provider config (skips empty values).
+// Headers builds the request headers for a provider: the common config keys (skipping empty values),
+// then the provider's headers map. Credentials are added per request by Authorize.
 func Headers(cfg Provider) map[string]string {
-	headers := make(map[string]string, 4)
+	headers := make(map[string]string, 4+len(cfg.Headers))
 
 	if v := ConfigString(cfg, ConfigUserAgentKey, ""); v != "" {
 		headers["User-Agent"] = v
@@ -39,6 +44,53 @@ func Headers(cfg Provider) map[string]string {
 	if v := ConfigString(cfg, ConfigCacheControlKey, ""); v != "" {
 		headers["Cache-Control"] = v
 	}
+	for k, v := range cfg.Headers {
+		if v != "" {
+			headers[k] = v
+		}
+	}
 
 	return headers
 }
+
+// ConfigDuration parses a Go duration string (e.g. "48h") for key from provider.Config or returns fallback.
+func ConfigDuration(cfg Provider, key string, fallback time.Duration) time.Duration {
+	raw := ConfigString(cfg, key, "")
+	if raw == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil || d <= 0 {
+		return fallback
+	}
+	return d
+}
+
+// ConfigInt reads a positive integer for key from provider.Config (number or numeric string) or returns fallback.
+func ConfigInt(cfg Provider, key string, fallback int) int {
+	if cfg.Config == nil {
+		return fallback
+	}
+
+	var n int
+	switch v := cfg.Config[key].(type) {
+	case int:
+		n = v
+	case int64:
+		n = int(v)
+	case float64:
+		n = int(v)
+	case string:
+		parsed, err := strconv.Atoi(strings.TrimSpace(v))
+		if err != nil {
+			return fallback
+		}
+		n = parsed
+	default:
+		return fallback
+	}
+	if n <= 0 {
+		return fallback
+	}
+	return n
+}
@@ -0,0 +1,110 @@
+package providers
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	// ConfigTimezoneKey names the IANA zone (e.g. "Asia/Kolkata") assumed for timestamps without an offset.
+	ConfigTimezoneKey = "timezone"
+
+	// FetchStatDateParseFailures counts non-empty dates in a run that matched none of the known layouts.
+	FetchStatDateParseFailures = "date_parse_failures"
+)
+
+// dateLayouts lists the date formats seen across sitemaps, feeds, APIs and HTML pages. Layouts carrying
+// an offset come first; the rest are naive and interpreted in the provider's timezone.
+var dateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04:05.999999999Z0700",
+	"2006-01-02T15:04Z0700",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999 Z07:00",
+	"2006-01-02 15:04:05.999999999 -0700",
+	"2006-01-02 15:04:05 MST",
+	time.RFC1123Z,
+	time.RFC1123,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 Z07:00",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"Mon, 2 Jan 2006 15:04 -0700",
+	"Mon, 2 Jan 2006 15:04 MST",
+	"2 Jan 2006 15:04:05 -0700",
+	"2 Jan 2006 15:04:05 MST",
+	time.RFC822Z,
+	time.RFC822,
+	time.RFC850,
+	time.UnixDate,
+
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"2006/01/02 15:04:05",
+	"2006/01/02",
+	"Mon, 2 Jan 2006 15:04:05",
+	"2 Jan 2006 15:04:05",
+	"2 Jan 2006 15:04",
+	"2 Jan 2006",
+	"January 2, 2006 15:04",
+	"January 2, 2006",
+	"Jan 2, 2006 15:04",
+	"Jan 2, 2006",
+	time.ANSIC,
+}
+
+// ProviderLocation returns the provider's configured timezone, or UTC when it is unset. Unknown names are
+// rejected when the provider is loaded; a Provider built in code with one also falls back to UTC.
+func ProviderLocation(cfg Provider) *time.Location {
+	name := ConfigString(cfg, ConfigTimezoneKey, "")
+	if name == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// ParseDate parses raw against the known layouts and returns it in UTC. Timestamps without an offset are
+// read in loc, as are zone abbreviations loc knows (e.g. IST for Asia/Kolkata). ok is false when raw is
+// blank or unparseable.
+func ParseDate(raw string, loc *time.Location) (t time.Time, ok bool) {
+	raw = strings.Join(strings.Fields(raw), " ")
+	if raw == "" {
+		return time.Time{}, false
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+	for _, layout := range dateLayouts {
+		if t, err := time.ParseInLocation(layout, raw, loc); err == nil {
+			return t.UTC(), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// dateParser parses the dates of a single provider run and counts the ones it could not read.
+type dateParser struct {
+	loc      *time.Location
+	failures int
+}
+
+// newDateParser builds a parser using the provider's timezone for naive timestamps.
+func newDateParser(cfg Provider) *dateParser {
+	return &dateParser{loc: ProviderLocation(cfg)}
+}
+
+// parse returns the parsed date or the zero time; blank values are not counted as failures.
+func (p *dateParser) parse(raw string) time.Time {
+	t, ok := ParseDate(raw, p.loc)
+	if !ok && strings.TrimSpace(raw) != "" {
+		p.failures++
+	}
+	return t
+}
@@ -0,0 +1,83 @@
+package providers
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestParseDateLayouts(t *testing.T) {
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	if err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+
+	cases := map[string]time.Time{
+		"2024-03-10T09:15:00+05:30":       time.Date(2024, 3, 10, 3, 45, 0, 0, time.UTC),
+		"2024-03-10T09:15:00.123Z":        time.Date(2024, 3, 10, 9, 15, 0, 123e6, time.UTC),
+		"2024-03-10T09:15:00+0530":        time.Date(2024, 3, 10, 3, 45, 0, 0, time.UTC),
+		"2024-03-10T09:15+05:30":          time.Date(2024, 3, 10, 3, 45, 0, 0, time.UTC),
+		"2024-03-10 09:15:00 +0530":       time.Date(2024, 3, 10, 3, 45, 0, 0, time.UTC),
+		"Sun, 10 Mar 2024 09:15:00 +0530": time.Date(2024, 3, 10, 3, 45, 0, 0, time.UTC),
+		"Sun, 10 Mar 2024 09:15:00 GMT":   time.Date(2024, 3, 10, 9, 15, 0, 0, time.UTC),
+		"Sun, 10 Mar 2024 09:15:00 IST":   time.Date(2024, 3, 10, 3, 45, 0, 0, time.UTC),
+		"Sun, 10 Mar 2024 9:15 +0530":     time.Date(2024, 3, 10, 3, 45, 0, 0, time.UTC),
+		"2024-03-10T09:15:00":             time.Date(2024, 3, 10, 3, 45, 0, 0, time.UTC),
+		"2024-03-10 09:15":                time.Date(2024, 3, 10, 3, 45, 0, 0, time.UTC),
+		"2024-03-10":                      time.Date(2024, 3, 9, 18, 30, 0, 0, time.UTC),
+		"March 10, 2024":                  time.Date(2024, 3, 9, 18, 30, 0, 0, time.UTC),
+		"  10 Mar  2024 ":                 time.Date(2024, 3, 9, 18, 30, 0, 0, time.UTC),
+	}
+	for raw, want := range cases {
+		got, ok := ParseDate(raw, kolkata)
+		if !ok || !got.Equal(want) {
+			t.Errorf("ParseDate(%q) = %v, %v; want %v", raw, got, ok, want)
+		}
+	}
+
+	if _, ok := ParseDate("", kolkata); ok {
+		t.Errorf("expected blank input to fail")
+	}
+	if got, _ := ParseDate("2024-03-10T09:15:00", nil); !got.Equal(time.Date(2024, 3, 10, 9, 15, 0, 0, time.UTC)) {
+		t.Errorf("expected naive timestamps to default to UTC, got %v", got)
+	}
+}
+
+func TestDateParserCountsFailures(t *testing.T) {
+	dates := newDateParser(Provider{Config: map[string]any{ConfigTimezoneKey: "Not/AZone"}})
+	if dates.loc != time.UTC {
+		t.Fatalf("expected unknown timezone to fall back to UTC, got %v", dates.loc)
+	}
+
+	dates.parse("2024-03-10")
+	dates.parse("")
+	dates.parse("   ")
+	dates.parse("last tuesday")
+	dates.parse("10/03/2024 garbage")
+	if dates.failures != 2 {
+		t.Fatalf("expected 2 failures, got %d", dates.failures)
+	}
+}
+
+func TestRSSFetcherReportsDateFailures(t *testing.T) {
+	feed := []byte(`<rss><channel>
+  <item><link>https://example.com/a</link><pubDate>Sun, 10 Mar 2024 09:15:00 +0530</pubDate></item>
+  <item><link>https://example.com/b</link><pubDate>10th March</pubDate></item>
+</channel></rss>`)
+	client := &fakeHTTPClient{responses: map[string]fakeResponse{
+		"https://example.com/feed.xml": {body: feed, statusCode: 200},
+	}}
+	fetcher := NewRSSFetcher(client, nil)
+
+	articles, err := fetcher.Fetch(context.Background(), Provider{ID: "p1", Type: ProviderTypeRSS, SourceURL: "https://example.com/feed.xml"})
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if len(articles) != 2 || articles[0].PublishedAt.IsZero() || !articles[1].PublishedAt.IsZero() {
+		t.Fatalf("unexpected articles %#v", articles)
+	}
+	stats := fetcher.(FetchStatsReporter).LastFetchStats("p1")
+	if stats[FetchStatDateParseFailures] != 1 {
+		t.Fatalf("expected 1 date failure in stats, got %v", stats)
+	}
+}
@@ -0,0 +1,230 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/samvad-hq/samvad-news-harvester/internal/domain"
+	"github.com/samvad-hq/samvad-news-harvester/pkg/robots"
+	"gopkg.in/yaml.v3"
+)
+
+// feedLinkTypes maps <link rel="alternate" type="..."> values to the provider type that reads them.
+var feedLinkTypes = map[string]string{
+	"application/rss+xml":   ProviderTypeRSS,
+	"application/atom+xml":  ProviderTypeAtom,
+	"application/feed+json": ProviderTypeJSONAPI,
+}
+
+// nonSlugChars matches runs of characters not allowed in generated provider ids.
+var nonSlugChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Candidate is a provider proposed by Discover together with the outcome of a test fetch.
+type Candidate struct {
+	Provider Provider
+	Articles int
+	Err      error
+}
+
+// Discover proposes providers for a site: the RSS/Atom/JSON feeds its homepage advertises with
+// <link rel="alternate"> and the sitemaps listed in its robots.txt. Each candidate is test-fetched with the
+// fetcher the registry resolves for it, and the article count or error is recorded. userAgent is sent with
+// every request and copied into the proposed config.
+func Discover(ctx context.Context, client HTTPClient, registry FetcherRegistry, siteURL, userAgent string) ([]Candidate, error) {
+	if client == nil {
+		client = DefaultHTTPClient()
+	}
+	if registry == nil {
+		registry = DefaultFetcherRegistry(client, nil)
+	}
+	root, err := discoveryRoot(siteURL)
+	if err != nil {
+		return nil, fmt.Errorf("site url: %w", err)
+	}
+	homepage := strings.TrimSpace(siteURL)
+	if !strings.Contains(homepage, "://") {
+		homepage = "https://" + homepage
+	}
+	headers := map[string]string{"User-Agent": userAgent}
+
+	var proposed []Provider
+	seen := make(map[string]struct{})
+	ids := make(map[string]int)
+	propose := func(typ, source, name string) {
+		if _, dup := seen[source]; dup || len(proposed) >= maxDiscoveryProbes {
+			return
+		}
+		seen[source] = struct{}{}
+		proposed = append(proposed, Provider{
+			ID:             uniqueSlug(hostName(root)+"-"+typ, ids),
+			Name:           firstNonBlank(name, hostName(root)),
+			Type:           typ,
+			SourceURL:      source,
+			ResponseFormat: ResponseFormatAuto,
+			Config:         map[string]any{ConfigUserAgentKey: userAgent},
+		})
+	}
+
+	for _, feed := range homepageFeeds(ctx, client, homepage, headers) {
+		propose(feed.typ, feed.url, feed.title)
+	}
+	if resp, err := client.Get(ctx, root+"/robots.txt", headers); err == nil && resp.StatusCode() == http.StatusOK {
+		for _, sitemap := range robots.Parse(resp.Body()).Sitemaps() {
+			if sitemap = strings.TrimSpace(sitemap); sitemap != "" {
+				propose(sitemapProviderType(sitemap), sitemap, "")
+			}
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if len(proposed) == 0 {
+		return nil, fmt.Errorf("no feeds or robots.txt sitemaps found for %s", homepage)
+	}
+
+	candidates := make([]Candidate, 0, len(proposed))
+	for _, p := range proposed {
+		articles, err := testFetch(ctx, registry, p)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		candidates = append(candidates, Candidate{Provider: p, Articles: len(articles), Err: err})
+	}
+	return candidates, nil
+}
+
+// testFetch runs the provider's fetcher once. Partial results count as success.
+func testFetch(ctx context.Context, registry FetcherRegistry, p Provider) ([]domain.Article, error) {
+	cfg := sanitizeProvider(p)
+	fetcher, err := registry.FetcherFor(cfg)
+	if err != nil {
+		return nil, err
+	}
+	articles, err := fetcher.Fetch(ctx, cfg)
+	if _, partial := IsPartial(err); partial {
+		err = nil
+	}
+	if err == nil && len(articles) == 0 {
+		err = fmt.Errorf("no articles found")
+	}
+	return articles, err
+}
+
+// homepageFeed is a feed advertised by a page.
+type homepageFeed struct {
+	typ   string
+	url   string
+	title string
+}
+
+// homepageFeeds reads the <link rel="alternate"> feeds of the page at pageURL, resolving relative hrefs.
+// Fetch or parse failures yield no feeds.
+func homepageFeeds(ctx context.Context, client HTTPClient, pageURL string, headers map[string]string) []homepageFeed {
+	resp, err := client.Get(ctx, pageURL, headers)
+	if err != nil || resp.StatusCode() != http.StatusOK {
+		return nil
+	}
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(resp.Body()))
+	if err != nil {
+		return nil
+	}
+	base, err := url.Parse(firstNonBlank(resp.URL(), pageURL))
+	if err != nil {
+		return nil
+	}
+	if href, ok := doc.Find("base[href]").First().Attr("href"); ok {
+		if u, err := base.Parse(strings.TrimSpace(href)); err == nil {
+			base = u
+		}
+	}
+
+	var feeds []homepageFeed
+	doc.Find("link[rel][href]").Each(func(_ int, s *goquery.Selection) {
+		rel, _ := s.Attr("rel")
+		if !slices.ContainsFunc(strings.Fields(rel), func(v string) bool { return strings.EqualFold(v, "alternate") }) {
+			return
+		}
+		typeAttr, _ := s.Attr("type")
+		typ, ok := feedLinkTypes[strings.ToLower(strings.TrimSpace(typeAttr))]
+		if !ok {
+			return
+		}
+		href, _ := s.Attr("href")
+		u, err := base.Parse(strings.TrimSpace(href))
+		if err != nil || !isHTTPURL(u.String()) {
+			return
+		}
+		title, _ := s.Attr("title")
+		feeds = append(feeds, homepageFeed{typ: typ, url: u.String(), title: strings.TrimSpace(title)})
+	})
+	return feeds
+}
+
+// sitemapProviderType guesses the provider type for a sitemap from its URL.
+func sitemapProviderType(sitemapURL string) string {
+	switch {
+	case looksLikeNewsSitemap(sitemapURL, []string{defaultDiscoveryChildMatch}):
+		return ProviderTypeGoogleNews
+	case strings.Contains(strings.ToLower(sitemapURL), "video"):
+		return ProviderTypeVideoSitemap
+	default:
+		return ProviderTypeSitemap
+	}
+}
+
+// uniqueSlug turns s into a provider id such as "example-com-rss", suffixed "-2", "-3", ... when used has
+// already handed it out.
+func uniqueSlug(s string, used map[string]int) string {
+	base := strings.Trim(nonSlugChars.ReplaceAllString(strings.ToLower(s), "-"), "-")
+	if base == "" {
+		base = "provider"
+	}
+	used[base]++
+	if n := used[base]; n > 1 {
+		return fmt.Sprintf("%s-%d", base, n)
+	}
+	return base
+}
+
+// hostName returns the lower-cased host of raw without a leading "www.".
+func hostName(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return raw
+	}
+	return strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+}
+
+// MarshalProvidersYAML renders providers as a providers file. comments, when given, are attached above the
+// provider at the same index.
+func MarshalProvidersYAML(providers []Provider, comments []string) ([]byte, error) {
+	var doc yaml.Node
+	if err := doc.Encode(registryFile{Providers: providers}); err != nil {
+		return nil, fmt.Errorf("encode providers: %w", err)
+	}
+	if len(doc.Content) == 2 {
+		for i, item := range doc.Content[1].Content {
+			if i < len(comments) {
+				item.HeadComment = comments[i]
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, fmt.Errorf("encode providers: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("encode providers: %w", err)
+	}
+	return buf.Bytes(), nil
+}
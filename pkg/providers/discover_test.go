@@ -0,0 +1,103 @@
+package providers
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestDiscoverTestsFeedsAndSitemaps(t *testing.T) {
+	homepage := []byte(`<html><head>
+  <link rel="stylesheet" href="/site.css">
+  <link rel="alternate" type="application/rss+xml" title="Example Top Stories" href="/feed.xml">
+  <link rel="alternate" type="application/atom+xml" href="https://example.com/atom.xml">
+  <link rel="alternate" hreflang="hi" href="https://example.com/hi/">
+</head><body></body></html>`)
+	rss := []byte(`<rss><channel>
+  <item><title>A</title><link>https://example.com/a</link></item>
+  <item><title>B</title><link>https://example.com/b</link></item>
+</channel></rss>`)
+	news := []byte(`<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9" xmlns:news="http://www.google.com/schemas/sitemap-news/0.9">
+  <url><loc>https://example.com/n1</loc><news:news><news:title>N1</news:title></news:news></url>
+</urlset>`)
+
+	client := &fakeHTTPClient{responses: map[string]fakeResponse{
+		"https://example.com":                  {body: homepage, statusCode: http.StatusOK},
+		"https://example.com/feed.xml":         {body: rss, statusCode: http.StatusOK},
+		"https://example.com/atom.xml":         {statusCode: http.StatusNotFound},
+		"https://example.com/robots.txt":       {body: []byte("User-agent: *\nSitemap: https://example.com/news-sitemap.xml\n"), statusCode: http.StatusOK},
+		"https://example.com/news-sitemap.xml": {body: news, statusCode: http.StatusOK},
+	}}
+
+	candidates, err := Discover(context.Background(), client, nil, "example.com", "test-agent")
+	if err != nil {
+		t.Fatalf("Discover: %v", err)
+	}
+	if len(candidates) != 3 {
+		t.Fatalf("expected 3 candidates, got %d: %+v", len(candidates), candidates)
+	}
+
+	feed := candidates[0]
+	if feed.Err != nil || feed.Articles != 2 {
+		t.Fatalf("expected rss feed with 2 articles, got %+v", feed)
+	}
+	if feed.Provider.ID != "example-com-rss" || feed.Provider.Name != "Example Top Stories" ||
+		feed.Provider.Type != ProviderTypeRSS || feed.Provider.SourceURL != "https://example.com/feed.xml" {
+		t.Fatalf("unexpected rss provider: %+v", feed.Provider)
+	}
+	if ConfigString(feed.Provider, ConfigUserAgentKey, "") != "test-agent" {
+		t.Fatalf("expected user agent in config, got %v", feed.Provider.Config)
+	}
+
+	if candidates[1].Provider.Type != ProviderTypeAtom || candidates[1].Err == nil {
+		t.Fatalf("expected failing atom candidate, got %+v", candidates[1])
+	}
+	sitemap := candidates[2]
+	if sitemap.Provider.Type != ProviderTypeGoogleNews || sitemap.Err != nil || sitemap.Articles != 1 {
+		t.Fatalf("unexpected sitemap candidate: %+v", sitemap)
+	}
+	if sitemap.Provider.Name != "example.com" {
+		t.Fatalf("expected host as sitemap provider name, got %q", sitemap.Provider.Name)
+	}
+}
+
+func TestDiscoverNothingFound(t *testing.T) {
+	client := &fakeHTTPClient{responses: map[string]fakeResponse{
+		"https://example.com/": {body: []byte("<html></html>"), statusCode: http.StatusOK},
+	}}
+	if _, err := Discover(context.Background(), client, nil, "https://example.com/", "ua"); err == nil {
+		t.Fatalf("expected error when the site advertises nothing")
+	}
+}
+
+func TestMarshalProvidersYAML(t *testing.T) {
+	data, err := MarshalProvidersYAML([]Provider{{
+		ID:             "example-com-rss",
+		Name:           "Example",
+		Type:           ProviderTypeRSS,
+		SourceURL:      "https://example.com/feed.xml",
+		ResponseFormat: ResponseFormatAuto,
+		Config:         map[string]any{ConfigUserAgentKey: "ua"},
+	}}, []string{"2 articles found"})
+	if err != nil {
+		t.Fatalf("MarshalProvidersYAML: %v", err)
+	}
+
+	out := string(data)
+	for _, want := range []string{"providers:\n", "# 2 articles found\n", "- id: example-com-rss\n", "    user_agent: ua\n"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in output:\n%s", want, out)
+		}
+	}
+	for _, unwanted := range []string{"source_urls", "request_delay_ms", "auth", "headers"} {
+		if strings.Contains(out, unwanted) {
+			t.Fatalf("expected %q to be omitted:\n%s", unwanted, out)
+		}
+	}
+
+	reg, err := parseRegistry(data, ".yaml")
+	if err != nil || len(reg.Providers) != 1 || reg.Providers[0].SourceURL != "https://example.com/feed.xml" {
+		t.Fatalf("round trip failed: %v %+v", err, reg)
+	}
+}
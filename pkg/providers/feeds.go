@@ -0,0 +1,282 @@
+package providers
+
+import (
+	"encoding/xml"
+	"strings"
+
+	"github.com/samvad-hq/samvad-news-harvester/internal/domain"
+)
+
+const mediaRSSNamespace = "http://search.yahoo.com/mrss/"
+
+type rssDocument struct {
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Items []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Link        string            `xml:"link"`
+	GUID        rssGUID           `xml:"guid"`
+	Title       string            `xml:"title"`
+	Description string            `xml:"description"`
+	PubDate     string            `xml:"pubDate"`
+	Categories  []string          `xml:"category"`
+	Enclosures  []rssEnclosure    `xml:"enclosure"`
+	Media       []mediaContent    `xml:"http://search.yahoo.com/mrss/ content"`
+	Thumbnails  []mediaThumbnail  `xml:"http://search.yahoo.com/mrss/ thumbnail"`
+	MediaGroups []mediaGroupEntry `xml:"http://search.yahoo.com/mrss/ group"`
+	Source      rssSource         `xml:"source"`
+}
+
+// rssSource is the <source> element naming the outlet an item came from (used by Google News).
+type rssSource struct {
+	Name string `xml:",chardata"`
+	URL  string `xml:"url,attr"`
+}
+
+type rssGUID struct {
+	Value       string `xml:",chardata"`
+	IsPermaLink string `xml:"isPermaLink,attr"`
+}
+
+type rssEnclosure struct {
+	URL  string `xml:"url,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type mediaContent struct {
+	URL    string `xml:"url,attr"`
+	Type   string `xml:"type,attr"`
+	Medium string `xml:"medium,attr"`
+}
+
+type mediaThumbnail struct {
+	URL string `xml:"url,attr"`
+}
+
+type mediaGroupEntry struct {
+	Media      []mediaContent   `xml:"http://search.yahoo.com/mrss/ content"`
+	Thumbnails []mediaThumbnail `xml:"http://search.yahoo.com/mrss/ thumbnail"`
+}
+
+type atomFeed struct {
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomEntry struct {
+	ID         string           `xml:"id"`
+	Title      string           `xml:"title"`
+	Summary    string           `xml:"summary"`
+	Content    string           `xml:"content"`
+	Updated    string           `xml:"updated"`
+	Published  string           `xml:"published"`
+	Links      []atomLink       `xml:"link"`
+	Categories []atomCategory   `xml:"category"`
+	Media      []mediaContent   `xml:"http://search.yahoo.com/mrss/ content"`
+	Thumbnails []mediaThumbnail `xml:"http://search.yahoo.com/mrss/ thumbnail"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type atomCategory struct {
+	Term  string `xml:"term,attr"`
+	Label string `xml:"label,attr"`
+}
+
+// parseRSSFeed parses the XML data into a slice of rssItem structs.
+func parseRSSFeed(data []byte) ([]rssItem, error) {
+	var doc rssDocument
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return doc.Channel.Items, nil
+}
+
+// parseAtomFeed parses the XML data into a slice of atomEntry structs.
+func parseAtomFeed(data []byte) ([]atomEntry, error) {
+	var feed atomFeed
+	if err := xml.Unmarshal(data, &feed); err != nil {
+		return nil, err
+	}
+	return feed.Entries, nil
+}
+
+// buildArticlesFromRSS constructs domain.Article instances from parsed RSS items.
+func buildArticlesFromRSS(providerID string, items []rssItem, dates *dateParser) []domain.Article {
+	articles := make([]domain.Article, 0, len(items))
+	for _, item := range items {
+		link := rssItemLink(item)
+		if link == "" {
+			continue
+		}
+
+		articles = append(articles, domain.Article{
+			ProviderID:  providerID,
+			ID:          hashURL(link),
+			Title:       strings.TrimSpace(item.Title),
+			URL:         link,
+			Description: strings.TrimSpace(item.Description),
+			ImageURL:    rssItemImage(item),
+			Keywords:    trimmedValues(item.Categories),
+			PublishedAt: dates.parse(item.PubDate),
+		})
+	}
+	return articles
+}
+
+// buildArticlesFromAtom constructs domain.Article instances from parsed Atom entries.
+func buildArticlesFromAtom(providerID string, entries []atomEntry, dates *dateParser) []domain.Article {
+	articles := make([]domain.Article, 0, len(entries))
+	for _, entry := range entries {
+		link := atomEntryLink(entry)
+		if link == "" {
+			continue
+		}
+
+		categories := make([]string, 0, len(entry.Categories))
+		for _, c := range entry.Categories {
+			categories = append(categories, firstNonBlank(c.Label, c.Term))
+		}
+
+		articles = append(articles, domain.Article{
+			ProviderID:  providerID,
+			ID:          hashURL(link),
+			Title:       strings.TrimSpace(entry.Title),
+			URL:         link,
+			Description: firstNonBlank(entry.Summary, entry.Content),
+			ImageURL:    atomEntryImage(entry),
+			Keywords:    trimmedValues(categories),
+			PublishedAt: dates.parse(firstNonBlank(entry.Published, entry.Updated)),
+		})
+	}
+	return articles
+}
+
+// rssItemLink returns the article URL for an RSS item, falling back to a permalink guid.
+func rssItemLink(item rssItem) string {
+	if link := strings.TrimSpace(item.Link); link != "" {
+		return link
+	}
+
+	guid := strings.TrimSpace(item.GUID.Value)
+	if guid == "" || strings.EqualFold(strings.TrimSpace(item.GUID.IsPermaLink), "false") {
+		return ""
+	}
+	if strings.HasPrefix(guid, "http://") || strings.HasPrefix(guid, "https://") {
+		return guid
+	}
+	return ""
+}
+
+// rssItemImage picks the first image from enclosures, media:content, or media:thumbnail.
+func rssItemImage(item rssItem) string {
+	for _, enc := range item.Enclosures {
+		if isImageType(enc.Type) {
+			if u := strings.TrimSpace(enc.URL); u != "" {
+				return u
+			}
+		}
+	}
+
+	media := item.Media
+	thumbs := item.Thumbnails
+	for _, group := range item.MediaGroups {
+		media = append(media, group.Media...)
+		thumbs = append(thumbs, group.Thumbnails...)
+	}
+	return mediaImage(media, thumbs)
+}
+
+// atomEntryLink returns the alternate link for an Atom entry.
+func atomEntryLink(entry atomEntry) string {
+	var fallback string
+	for _, l := range entry.Links {
+		href := strings.TrimSpace(l.Href)
+		if href == "" {
+			continue
+		}
+		switch strings.ToLower(strings.TrimSpace(l.Rel)) {
+		case "", "alternate":
+			return href
+		}
+		if fallback == "" && !strings.EqualFold(l.Rel, "enclosure") && !strings.EqualFold(l.Rel, "self") {
+			fallback = href
+		}
+	}
+	if fallback != "" {
+		return fallback
+	}
+
+	id := strings.TrimSpace(entry.ID)
+	if strings.HasPrefix(id, "http://") || strings.HasPrefix(id, "https://") {
+		return id
+	}
+	return ""
+}
+
+// atomEntryImage picks the first image from enclosure links or media elements.
+func atomEntryImage(entry atomEntry) string {
+	for _, l := range entry.Links {
+		if strings.EqualFold(strings.TrimSpace(l.Rel), "enclosure") && isImageType(l.Type) {
+			if href := strings.TrimSpace(l.Href); href != "" {
+				return href
+			}
+		}
+	}
+	return mediaImage(entry.Media, entry.Thumbnails)
+}
+
+// mediaImage returns the first image-like media:content URL, falling back to media:thumbnail.
+func mediaImage(media []mediaContent, thumbs []mediaThumbnail) string {
+	for _, m := range media {
+		u := strings.TrimSpace(m.URL)
+		if u == "" {
+			continue
+		}
+		if strings.EqualFold(strings.TrimSpace(m.Medium), "image") || isImageType(m.Type) {
+			return u
+		}
+	}
+	for _, t := range thumbs {
+		if u := strings.TrimSpace(t.URL); u != "" {
+			return u
+		}
+	}
+	return ""
+}
+
+// isImageType reports whether the MIME type denotes an image.
+func isImageType(mime string) bool {
+	return strings.HasPrefix(strings.ToLower(strings.TrimSpace(mime)), "image/")
+}
+
+// trimmedValues trims each value and drops blanks.
+func trimmedValues(values []string) []string {
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+// firstNonBlank returns the first non-blank value, trimmed.
+func firstNonBlank(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
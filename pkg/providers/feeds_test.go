@@ -0,0 +1,149 @@
+package providers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestParseRSSFeed(t *testing.T) {
+	data := []byte(`
+<rss version="2.0" xmlns:media="http://search.yahoo.com/mrss/">
+  <channel>
+    <title>Example</title>
+    <item>
+      <title>First</title>
+      <link>https://example.com/first</link>
+      <description>First story</description>
+      <pubDate>Mon, 01 Jan 2024 05:30:00 +0530</pubDate>
+      <category>India</category>
+      <category> Politics </category>
+      <enclosure url="https://example.com/first.jpg" type="image/jpeg" length="0"/>
+    </item>
+    <item>
+      <title>Second</title>
+      <guid isPermaLink="true">https://example.com/second</guid>
+      <media:content url="https://example.com/second.mp4" medium="video"/>
+      <media:thumbnail url="https://example.com/second.jpg"/>
+    </item>
+    <item>
+      <title>No link</title>
+      <guid isPermaLink="false">abc-123</guid>
+    </item>
+  </channel>
+</rss>`)
+
+	items, err := parseRSSFeed(data)
+	if err != nil {
+		t.Fatalf("parseRSSFeed: %v", err)
+	}
+	if len(items) != 3 {
+		t.Fatalf("expected 3 items, got %d", len(items))
+	}
+
+	articles := buildArticlesFromRSS("rss-p", items, &dateParser{loc: time.UTC})
+	if len(articles) != 2 {
+		t.Fatalf("expected 2 articles after dropping linkless item, got %d", len(articles))
+	}
+
+	first := articles[0]
+	if first.URL != "https://example.com/first" || first.Title != "First" || first.Description != "First story" {
+		t.Errorf("unexpected first article %+v", first)
+	}
+	if !first.PublishedAt.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("PublishedAt = %v", first.PublishedAt)
+	}
+	if len(first.Keywords) != 2 || first.Keywords[1] != "Politics" {
+		t.Errorf("Keywords = %#v", first.Keywords)
+	}
+	if first.ImageURL != "https://example.com/first.jpg" {
+		t.Errorf("ImageURL = %s", first.ImageURL)
+	}
+
+	second := articles[1]
+	if second.URL != "https://example.com/second" {
+		t.Errorf("expected guid permalink fallback, got %s", second.URL)
+	}
+	if second.ImageURL != "https://example.com/second.jpg" {
+		t.Errorf("expected thumbnail fallback, got %s", second.ImageURL)
+	}
+}
+
+func TestParseAtomFeed(t *testing.T) {
+	data := []byte(`
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <entry>
+    <id>tag:example.com,2024:1</id>
+    <title>Atom story</title>
+    <link rel="self" href="https://example.com/feed/1"/>
+    <link rel="alternate" href="https://example.com/atom-story"/>
+    <link rel="enclosure" type="image/png" href="https://example.com/atom.png"/>
+    <summary>Summary text</summary>
+    <updated>2024-02-01T10:00:00Z</updated>
+    <category term="science" label="Science"/>
+  </entry>
+  <entry>
+    <id>https://example.com/from-id</id>
+    <title>Id only</title>
+  </entry>
+</feed>`)
+
+	entries, err := parseAtomFeed(data)
+	if err != nil {
+		t.Fatalf("parseAtomFeed: %v", err)
+	}
+
+	articles := buildArticlesFromAtom("atom-p", entries, &dateParser{loc: time.UTC})
+	if len(articles) != 2 {
+		t.Fatalf("expected 2 articles, got %d", len(articles))
+	}
+
+	art := articles[0]
+	if art.URL != "https://example.com/atom-story" {
+		t.Errorf("URL = %s", art.URL)
+	}
+	if art.ImageURL != "https://example.com/atom.png" {
+		t.Errorf("ImageURL = %s", art.ImageURL)
+	}
+	if art.Description != "Summary text" {
+		t.Errorf("Description = %s", art.Description)
+	}
+	if len(art.Keywords) != 1 || art.Keywords[0] != "Science" {
+		t.Errorf("Keywords = %#v", art.Keywords)
+	}
+	if !art.PublishedAt.Equal(time.Date(2024, 2, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("PublishedAt = %v", art.PublishedAt)
+	}
+	if articles[1].URL != "https://example.com/from-id" {
+		t.Errorf("expected id fallback, got %s", articles[1].URL)
+	}
+}
+
+func TestRSSFetcherFetch(t *testing.T) {
+	client := &fakeHTTPClient{
+		responses: map[string]fakeResponse{
+			"https://example.com/rss": {
+				body:       []byte(`<rss><channel><item><link>https://example.com/a</link></item></channel></rss>`),
+				statusCode: http.StatusOK,
+			},
+		},
+	}
+
+	fetcher := NewRSSFetcher(client, nil)
+	articles, err := fetcher.Fetch(context.Background(), Provider{
+		ID:        "p1",
+		Type:      ProviderTypeRSS,
+		SourceURL: "https://example.com/rss",
+	})
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if len(articles) != 1 || articles[0].URL != "https://example.com/a" {
+		t.Fatalf("unexpected articles %#v", articles)
+	}
+
+	if _, err := fetcher.Fetch(context.Background(), Provider{ID: "p1", Type: ProviderTypeAtom, SourceURL: "x"}); err == nil {
+		t.Fatalf("expected error for incompatible provider type")
+	}
+}
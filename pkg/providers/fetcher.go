@@ -68,8 +68,21 @@ func (r *fetcherRegistry) registerTypeFetcher(typ string, f Fetcher) {
 	r.mu.Unlock()
 }
 
-// FetcherFor selects the fetcher for the given provider based on its id or type.
+// FetcherFor selects the fetcher for the given provider based on its id or type. Providers with source_urls
+// or a dated source_url template get the fetcher wrapped so every rendered source is fetched.
 func (r *fetcherRegistry) FetcherFor(cfg Provider) (Fetcher, error) {
+	f, err := r.fetcherFor(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.hasMultipleSources() {
+		return newMultiSourceFetcher(f), nil
+	}
+	return f, nil
+}
+
+// fetcherFor resolves the registered fetcher for cfg by id, then by type.
+func (r *fetcherRegistry) fetcherFor(cfg Provider) (Fetcher, error) {
 	if r == nil {
 		return nil, fmt.Errorf("fetcher registry is nil")
 	}
@@ -98,16 +111,37 @@ func (r *fetcherRegistry) FetcherFor(cfg Provider) (Fetcher, error) {
 // DefaultHTTPClient returns a tuned http.Client for provider fetchers.
 func DefaultHTTPClient() HTTPClient { return httpclient.NewRestyClient(15 * time.Second) }
 
-const ProviderTypeGoogleNews = "google_news_sitemap"
+const (
+	ProviderTypeGoogleNews       = "google_news_sitemap"
+	ProviderTypeRSS              = "rss"
+	ProviderTypeAtom             = "atom"
+	ProviderTypeSitemap          = "sitemap"
+	ProviderTypeJSONAPI          = "json_api"
+	ProviderTypeHTMLListing      = "html_listing"
+	ProviderTypeSitemapDiscovery = "sitemap_discovery"
+	ProviderTypeVideoSitemap     = "video_sitemap"
+	ProviderTypeLinkList         = "link_list"
+	ProviderTypeGoogleNewsRSS    = "google_news_rss"
+)
 
 // DefaultFetcherRegistry wires up known provider fetchers.
-func DefaultFetcherRegistry(client HTTPClient) FetcherRegistry {
+// validators is optional and enables conditional GETs for provider sources.
+func DefaultFetcherRegistry(client HTTPClient, validators ValidatorStore) FetcherRegistry {
 	if client == nil {
 		client = DefaultHTTPClient()
 	}
 
 	typeFetchers := map[string]Fetcher{
-		ProviderTypeGoogleNews: NewGoogleNewsFetcher(client),
+		ProviderTypeGoogleNews:       NewGoogleNewsFetcher(client, validators),
+		ProviderTypeRSS:              NewRSSFetcher(client, validators),
+		ProviderTypeAtom:             NewAtomFetcher(client, validators),
+		ProviderTypeSitemap:          NewSitemapFetcher(client, validators),
+		ProviderTypeJSONAPI:          NewJSONAPIFetcher(client, validators),
+		ProviderTypeHTMLListing:      NewHTMLListingFetcher(client, validators),
+		ProviderTypeSitemapDiscovery: NewSitemapDiscoveryFetcher(client, validators),
+		ProviderTypeVideoSitemap:     NewVideoSitemapFetcher(client, validators),
+		ProviderTypeLinkList:         NewLinkListFetcher(client, validators),
+		ProviderTypeGoogleNewsRSS:    NewGoogleNewsRSSFetcher(client, validators),
 	}
 
 	return NewTypeFetcherRegistry(typeFetchers)
@@ -0,0 +1,183 @@
+package providers
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Supported provider response formats.
+const (
+	ResponseFormatXML     = "xml"
+	ResponseFormatXMLGzip = "xml.gz"
+	ResponseFormatJSON    = "json"
+	ResponseFormatHTML    = "html"
+	ResponseFormatAuto    = "auto"
+
+	// maxDecompressedBytes caps gzip expansion so a hostile archive cannot exhaust memory.
+	maxDecompressedBytes = 64 << 20 // 64 MiB
+	// sniffLen is how many leading bytes are inspected when sniffing a payload.
+	sniffLen = 512
+)
+
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// validResponseFormat reports whether format is one of the supported response formats.
+func validResponseFormat(format string) bool {
+	switch format {
+	case ResponseFormatXML, ResponseFormatXMLGzip, ResponseFormatJSON, ResponseFormatHTML, ResponseFormatAuto:
+		return true
+	default:
+		return false
+	}
+}
+
+// decodeBody applies the declared response format to body and returns the payload together with the
+// resolved format (xml, json or html). Gzip payloads are decompressed whenever the magic bytes are present;
+// "auto" (or an empty format) sniffs the payload and Content-Type.
+func decodeBody(body []byte, contentType, format string) ([]byte, string, error) {
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format == "" {
+		format = ResponseFormatAuto
+	}
+
+	if bytes.HasPrefix(body, gzipMagic) {
+		inflated, err := gunzip(body)
+		if err != nil {
+			return nil, "", err
+		}
+		body = inflated
+		contentType = "" // describes the archive, not the payload
+	} else if format == ResponseFormatXMLGzip && !looksLikeMarkup(body) {
+		return nil, "", fmt.Errorf("response_format %s but body is not gzip compressed", format)
+	}
+
+	switch format {
+	case ResponseFormatXML, ResponseFormatXMLGzip:
+		return body, ResponseFormatXML, nil
+	case ResponseFormatJSON, ResponseFormatHTML:
+		return body, format, nil
+	case ResponseFormatAuto:
+		return body, sniffFormat(body, contentType), nil
+	default:
+		return nil, "", fmt.Errorf("unsupported response_format %q", format)
+	}
+}
+
+// openXMLStream is the streaming counterpart of decodeBody for callers that can only parse XML: it
+// transparently inflates gzip streams and rejects bodies that the declared or sniffed format says are not XML.
+func openXMLStream(body io.Reader, contentType, format string) (io.Reader, error) {
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format == "" {
+		format = ResponseFormatAuto
+	}
+	if !validResponseFormat(format) {
+		return nil, fmt.Errorf("unsupported response_format %q", format)
+	}
+
+	br := bufio.NewReader(body)
+	head, _ := br.Peek(sniffLen)
+	var r io.Reader = br
+	if bytes.HasPrefix(head, gzipMagic) {
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("open gzip body: %w", err)
+		}
+		inflated := bufio.NewReader(&cappedReader{r: zr, remaining: maxDecompressedBytes})
+		head, _ = inflated.Peek(sniffLen)
+		r = inflated
+		contentType = "" // describes the archive, not the payload
+	} else if format == ResponseFormatXMLGzip && !looksLikeMarkup(head) {
+		return nil, fmt.Errorf("response_format %s but body is not gzip compressed", format)
+	}
+
+	resolved := format
+	switch format {
+	case ResponseFormatXMLGzip:
+		resolved = ResponseFormatXML
+	case ResponseFormatAuto:
+		resolved = sniffFormat(head, contentType)
+	}
+	if resolved != ResponseFormatXML && resolved != ResponseFormatAuto {
+		return nil, fmt.Errorf("body is %s, expected %s", resolved, ResponseFormatXML)
+	}
+	return r, nil
+}
+
+// cappedReader fails once more than remaining bytes have been read, bounding gzip expansion for streams.
+type cappedReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+func (c *cappedReader) Read(p []byte) (int, error) {
+	if c.remaining <= 0 {
+		return 0, fmt.Errorf("decompressed body exceeds %d bytes", maxDecompressedBytes)
+	}
+	if int64(len(p)) > c.remaining {
+		p = p[:c.remaining]
+	}
+	n, err := c.r.Read(p)
+	c.remaining -= int64(n)
+	return n, err
+}
+
+// gunzip decompresses body, bounded by maxDecompressedBytes.
+func gunzip(body []byte) ([]byte, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("open gzip body: %w", err)
+	}
+	defer zr.Close()
+
+	out, err := io.ReadAll(io.LimitReader(zr, maxDecompressedBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("decompress gzip body: %w", err)
+	}
+	if len(out) > maxDecompressedBytes {
+		return nil, fmt.Errorf("decompressed body exceeds %d bytes", maxDecompressedBytes)
+	}
+	return out, nil
+}
+
+// sniffFormat guesses the payload format from its leading bytes, falling back to the Content-Type header.
+func sniffFormat(body []byte, contentType string) string {
+	head := bytes.TrimLeft(bytes.TrimPrefix(body, []byte("\xef\xbb\xbf")), " \t\r\n")
+	if len(head) > sniffLen {
+		head = head[:sniffLen]
+	}
+	lower := bytes.ToLower(head)
+
+	switch {
+	case bytes.HasPrefix(head, []byte("{")), bytes.HasPrefix(head, []byte("[")):
+		return ResponseFormatJSON
+	case bytes.HasPrefix(lower, []byte("<!doctype html")), bytes.HasPrefix(lower, []byte("<html")):
+		return ResponseFormatHTML
+	case bytes.HasPrefix(lower, []byte("<?xml")):
+		return ResponseFormatXML
+	}
+
+	ct := strings.ToLower(contentType)
+	switch {
+	case strings.Contains(ct, "json"):
+		return ResponseFormatJSON
+	case strings.Contains(ct, "html"):
+		return ResponseFormatHTML
+	case strings.Contains(ct, "xml"), strings.Contains(ct, "rss"), strings.Contains(ct, "atom"):
+		return ResponseFormatXML
+	}
+
+	if bytes.HasPrefix(head, []byte("<")) {
+		return ResponseFormatXML
+	}
+	return ResponseFormatAuto
+}
+
+// looksLikeMarkup reports whether body starts with an XML/HTML tag (servers sometimes inflate .gz files for us).
+func looksLikeMarkup(body []byte) bool {
+	head := bytes.TrimLeft(bytes.TrimPrefix(body, []byte("\xef\xbb\xbf")), " \t\r\n")
+	return bytes.HasPrefix(head, []byte("<"))
+}
@@ -0,0 +1,105 @@
+package providers
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"testing"
+)
+
+func gzipBytes(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		t.Fatalf("gzip write: %v", err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("gzip close: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestDecodeBodyFormats(t *testing.T) {
+	xmlBody := []byte(`<?xml version="1.0"?><urlset></urlset>`)
+
+	cases := []struct {
+		name        string
+		body        []byte
+		contentType string
+		format      string
+		want        string
+		wantErr     bool
+	}{
+		{name: "declared xml", body: xmlBody, format: "xml", want: ResponseFormatXML},
+		{name: "declared xml.gz", body: gzipBytes(t, xmlBody), format: "xml.gz", want: ResponseFormatXML},
+		{name: "xml.gz already inflated", body: xmlBody, format: "xml.gz", want: ResponseFormatXML},
+		{name: "xml.gz garbage", body: []byte("not gzip"), format: "xml.gz", wantErr: true},
+		{name: "gzip under plain xml", body: gzipBytes(t, xmlBody), format: "xml", want: ResponseFormatXML},
+		{name: "auto json body", body: []byte(` {"items": []}`), format: "auto", want: ResponseFormatJSON},
+		{name: "auto html body", body: []byte("<!DOCTYPE html><html></html>"), format: "auto", want: ResponseFormatHTML},
+		{name: "auto content type", body: []byte("<rss></rss>"), contentType: "application/rss+xml", format: "auto", want: ResponseFormatXML},
+		{name: "auto gzip", body: gzipBytes(t, []byte(`[1]`)), contentType: "application/x-gzip", format: "auto", want: ResponseFormatJSON},
+		{name: "empty means auto", body: xmlBody, format: "", want: ResponseFormatXML},
+		{name: "unknown", body: xmlBody, format: "yaml", wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, got, err := decodeBody(tc.body, tc.contentType, tc.format)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got format %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("decodeBody: %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("format = %q want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFetchSitemapDecompressesAndChecksFormat(t *testing.T) {
+	leaf := []byte(`<urlset><url><loc>https://example.com/a</loc></url></urlset>`)
+	client := &fakeHTTPClient{
+		responses: map[string]fakeResponse{
+			"https://example.com/news.xml.gz": {body: gzipBytes(t, leaf), statusCode: http.StatusOK},
+			"https://example.com/api": {
+				body:       []byte(`{"ok": true}`),
+				statusCode: http.StatusOK,
+				header:     http.Header{"Content-Type": []string{"application/json"}},
+			},
+		},
+	}
+
+	doc, err := streamSitemapDocument[sitemapURL](context.Background(), client, "https://example.com/news.xml.gz", Provider{ID: "p1", ResponseFormat: ResponseFormatXMLGzip}, nil, 0)
+	if err != nil {
+		t.Fatalf("streamSitemapDocument: %v", err)
+	}
+	if len(doc.URLs) != 1 || doc.URLs[0].Loc != "https://example.com/a" {
+		t.Fatalf("expected decompressed entries, got %#v", doc.URLs)
+	}
+
+	if _, err := streamSitemapDocument[sitemapURL](context.Background(), client, "https://example.com/api", Provider{ID: "p1", ResponseFormat: ResponseFormatAuto}, nil, 0); err == nil {
+		t.Fatalf("expected format mismatch error for json body")
+	}
+}
+
+func TestLoadRegistryRejectsUnknownResponseFormat(t *testing.T) {
+	path := writeTempFile(t, t.TempDir(), "providers.yaml", `
+providers:
+  - id: foo
+    name: Foo
+    type: rss
+    source_url: https://example.com/rss
+    response_format: csv
+`)
+	if _, err := LoadRegistry(path); err == nil {
+		t.Fatalf("expected error for unsupported response_format")
+	}
+}
@@ -11,14 +11,17 @@ import (
 // googleNewsFetcher implements Fetcher for Google News sitemap providers.
 type googleNewsFetcher struct {
 	client HTTPClient
+	validatorCache
+	fetchStats
 }
 
 // NewGoogleNewsFetcher builds a Fetcher for Google News sitemap providers.
-func NewGoogleNewsFetcher(client HTTPClient) Fetcher {
+// validators is optional; when set, unchanged leaf sitemaps are skipped via conditional GETs.
+func NewGoogleNewsFetcher(client HTTPClient, validators ValidatorStore) Fetcher {
 	if client == nil {
 		client = DefaultHTTPClient()
 	}
-	return &googleNewsFetcher{client: client}
+	return &googleNewsFetcher{client: client, validatorCache: validatorCache{store: validators}}
 }
 
 // ID returns the provider type for the Google News fetcher.
@@ -37,61 +40,37 @@ func (f *googleNewsFetcher) Fetch(ctx context.Context, cfg Provider) ([]domain.A
 
 	headers := Headers(cfg)
 
-	urls, err := f.fetchGoogleNewsURLs(ctx, cfg, cfg.SourceURL, headers, nil)
-	if err != nil {
+	// A *PartialError means some nested sitemaps failed; whatever the others produced is still returned.
+	urls, unchanged, stats, err := f.fetchGoogleNewsURLs(ctx, cfg, cfg.SourceURL, headers)
+	if _, partial := IsPartial(err); err != nil && !partial {
+		return nil, err
+	}
+	if len(urls) == 0 && unchanged {
 		return nil, err
 	}
 
-	articles := buildArticlesFromSitemap(cfg.ID, urls)
+	fallbacks := applyNamespaceMode(cfg, urls)
+	dates := newDateParser(cfg)
+	articles := buildArticlesFromSitemap(cfg.ID, urls, dates)
+	stats[FetchStatNamespaceFallbacks] = fallbacks
+	stats[FetchStatDateParseFailures] = dates.failures
+	f.record(cfg.ID, stats)
 	if len(articles) == 0 {
 		return nil, fmt.Errorf("%s sitemap returned no records", cfg.ID)
 	}
-	return articles, nil
+	return articles, err
 }
 
 // fetchGoogleNewsURLs resolves the given sitemap URL into article entries, following sitemap indexes if necessary.
-func (f *googleNewsFetcher) fetchGoogleNewsURLs(ctx context.Context, cfg Provider, url string, headers map[string]string, visited map[string]struct{}) ([]googleNewsURL, error) {
-	if visited == nil {
-		visited = make(map[string]struct{})
-	}
-	if _, seen := visited[url]; seen {
-		return nil, nil
-	}
-	visited[url] = struct{}{}
-
-	raw, err := fetchSitemap(ctx, f.client, url, cfg.ID, headers)
-	if err != nil {
-		return nil, err
-	}
-
-	urls, err := parseGoogleNewsSitemap(raw)
-	if err != nil {
-		return nil, fmt.Errorf("decode google news sitemap: %w", err)
-	}
-	if len(urls) > 0 {
-		return urls, nil
-	}
-
-	indexURLs, err := parseSitemapIndex(raw)
+// unchanged reports whether any leaf sitemap answered 304 Not Modified, and stats counts the index children
+// the walk's limits skipped. Failed nested sitemaps are reported through a *PartialError when other leaves
+// succeeded.
+func (f *googleNewsFetcher) fetchGoogleNewsURLs(ctx context.Context, cfg Provider, url string, headers map[string]string) (urls []googleNewsURL, unchanged bool, stats map[string]int, err error) {
+	walker := newSitemapWalker[googleNewsURL](f.client, f.store, cfg, headers)
+	urls, err = walker.walk(ctx, url)
 	if err != nil {
-		return nil, fmt.Errorf("decode sitemap index: %w", err)
-	}
-	if len(indexURLs) == 0 {
-		return nil, nil
-	}
-
-	var all []googleNewsURL
-	for _, indexURL := range indexURLs {
-		indexURL = strings.TrimSpace(indexURL)
-		if indexURL == "" {
-			continue
-		}
-
-		nested, err := f.fetchGoogleNewsURLs(ctx, cfg, indexURL, headers, visited)
-		if err != nil {
-			return nil, err
-		}
-		all = append(all, nested...)
+		return nil, false, nil, err
 	}
-	return all, nil
+	f.hold(cfg.ID, walker.validators())
+	return urls, walker.unchanged > 0, walker.limitStats(), walker.failureErr()
 }
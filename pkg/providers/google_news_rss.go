@@ -0,0 +1,297 @@
+package providers
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/samvad-hq/samvad-news-harvester/internal/domain"
+	"github.com/samvad-hq/samvad-news-harvester/pkg/httpclient"
+)
+
+const (
+	// ConfigQueryKey is the Google News search query (e.g. "ISRO" or "monsoon when:1d").
+	ConfigQueryKey = "query"
+	// ConfigTopicKey is a Google News topic id, or a section name such as WORLD or TECHNOLOGY.
+	ConfigTopicKey = "topic"
+	// ConfigLanguageKey is the interface language (hl), e.g. "en-IN" or "hi".
+	ConfigLanguageKey = "language"
+	// ConfigRegionKey is the edition country (gl), e.g. "IN".
+	ConfigRegionKey = "region"
+
+	// FetchStatRedirectsResolved counts Google News links resolved to the publisher URL by following them.
+	FetchStatRedirectsResolved = "redirects_resolved"
+	// FetchStatRedirectsUndecoded counts Google News items dropped because their link could neither be
+	// decoded nor resolved to the publisher URL.
+	FetchStatRedirectsUndecoded = "redirects_undecoded"
+
+	googleNewsRSSBase     = "https://news.google.com/rss"
+	defaultGoogleLanguage = "en-US"
+	defaultGoogleRegion   = "US"
+
+	// maxGoogleNewsResolves caps how many links a single run follows; the rest are dropped until the next run.
+	maxGoogleNewsResolves = 100
+	// maxResolvedLinks bounds the cache of followed links, which is reset when full.
+	maxResolvedLinks = 4096
+)
+
+// googleNewsSections are the named headline sections served under /rss/headlines/section/topic/.
+var googleNewsSections = map[string]struct{}{
+	"WORLD": {}, "NATION": {}, "BUSINESS": {}, "TECHNOLOGY": {}, "ENTERTAINMENT": {},
+	"SPORTS": {}, "SCIENCE": {}, "HEALTH": {},
+}
+
+// googleNewsRSSFetcher implements Fetcher for Google News RSS search and topic feeds.
+type googleNewsRSSFetcher struct {
+	client HTTPClient
+
+	mu sync.Mutex
+	// resolved caches the publisher URL each followed news.google.com link landed on, so items that stay
+	// in the feed are not followed again on every run.
+	resolved map[string]string
+	validatorCache
+	fetchStats
+}
+
+// NewGoogleNewsRSSFetcher builds a Fetcher for google_news_rss providers.
+// validators is optional; when set, unchanged feeds are skipped via conditional GETs.
+func NewGoogleNewsRSSFetcher(client HTTPClient, validators ValidatorStore) Fetcher {
+	if client == nil {
+		client = DefaultHTTPClient()
+	}
+	return &googleNewsRSSFetcher{
+		client:         client,
+		resolved:       make(map[string]string),
+		validatorCache: validatorCache{store: validators},
+	}
+}
+
+// ID returns the provider type for the Google News RSS fetcher.
+func (f *googleNewsRSSFetcher) ID() string {
+	return ProviderTypeGoogleNewsRSS
+}
+
+// Fetch retrieves a Google News RSS feed and maps each item to the publisher's article.
+func (f *googleNewsRSSFetcher) Fetch(ctx context.Context, cfg Provider) ([]domain.Article, error) {
+	if !strings.EqualFold(cfg.Type, ProviderTypeGoogleNewsRSS) {
+		return nil, fmt.Errorf("google news rss fetcher received incompatible provider type %q", cfg.Type)
+	}
+	source := cfg.SourceURL
+	if strings.TrimSpace(source) == "" {
+		source = GoogleNewsRSSURL(cfg)
+	}
+	if source == "" {
+		return nil, fmt.Errorf("provider %q needs source_url or a %s/%s config value", cfg.ID, ConfigQueryKey, ConfigTopicKey)
+	}
+
+	doc, err := fetchDocument(ctx, f.client, source, cfg, "feed", ResponseFormatXML, conditionalHeaders(f.store, source, Headers(cfg)))
+	if err != nil {
+		return nil, err
+	}
+	if doc.NotModified {
+		f.holdSource(cfg.ID, source, doc.Validators)
+		return nil, nil
+	}
+
+	items, err := parseRSSFeed(doc.Body)
+	if err != nil {
+		return nil, fmt.Errorf("decode google news rss feed: %w", err)
+	}
+
+	dates := newDateParser(cfg)
+	articles := buildArticlesFromGoogleNewsRSS(cfg.ID, items, dates)
+	articles, resolved, undecoded := f.resolveLinks(ctx, cfg, articles)
+	f.record(cfg.ID, map[string]int{
+		FetchStatDateParseFailures:  dates.failures,
+		FetchStatRedirectsResolved:  resolved,
+		FetchStatRedirectsUndecoded: undecoded,
+	})
+	if len(articles) == 0 {
+		return nil, fmt.Errorf("%s feed returned no records", cfg.ID)
+	}
+	f.holdSource(cfg.ID, source, doc.Validators)
+	return articles, nil
+}
+
+// GoogleNewsRSSURL builds the feed URL for the provider's query or topic config, or returns "" when neither
+// is set. A query takes precedence over a topic.
+func GoogleNewsRSSURL(cfg Provider) string {
+	language := ConfigString(cfg, ConfigLanguageKey, defaultGoogleLanguage)
+	region := strings.ToUpper(ConfigString(cfg, ConfigRegionKey, defaultGoogleRegion))
+
+	var path string
+	q := url.Values{}
+	if query := ConfigString(cfg, ConfigQueryKey, ""); query != "" {
+		path = "/search"
+		q.Set("q", query)
+	} else if topic := ConfigString(cfg, ConfigTopicKey, ""); topic != "" {
+		if _, ok := googleNewsSections[strings.ToUpper(topic)]; ok {
+			path = "/headlines/section/topic/" + strings.ToUpper(topic)
+		} else {
+			path = "/topics/" + url.PathEscape(topic)
+		}
+	} else {
+		return ""
+	}
+
+	q.Set("hl", language)
+	q.Set("gl", region)
+	q.Set("ceid", region+":"+strings.TrimSuffix(language, "-"+region))
+	return googleNewsRSSBase + path + "?" + q.Encode()
+}
+
+// buildArticlesFromGoogleNewsRSS maps Google News items to publisher articles. Links are decoded from the
+// news.google.com redirect where possible; items whose link cannot be decoded keep it for resolveLinks.
+func buildArticlesFromGoogleNewsRSS(providerID string, items []rssItem, dates *dateParser) []domain.Article {
+	articles := make([]domain.Article, 0, len(items))
+	for _, item := range items {
+		link := strings.TrimSpace(item.Link)
+		if link == "" {
+			continue
+		}
+		if publisher, ok := decodeGoogleNewsLink(link); ok {
+			link = publisher
+		}
+
+		outlet := strings.TrimSpace(item.Source.Name)
+		articles = append(articles, domain.Article{
+			ProviderID:      providerID,
+			ID:              hashURL(link),
+			Title:           googleNewsTitle(item.Title, outlet),
+			URL:             link,
+			PublicationName: outlet,
+			PublishedAt:     dates.parse(item.PubDate),
+		})
+	}
+	return articles
+}
+
+// resolveLinks follows the news.google.com links buildArticlesFromGoogleNewsRSS could not decode and
+// replaces them, and the article IDs, with the publisher URL they redirect to. Items whose link does not
+// leave Google are dropped: publishing them would dedupe and enrich Google's redirect page instead of
+// the article.
+func (f *googleNewsRSSFetcher) resolveLinks(ctx context.Context, cfg Provider, articles []domain.Article) (kept []domain.Article, resolved, dropped int) {
+	kept = articles[:0]
+	followed := 0
+	for _, art := range articles {
+		if !isGoogleNewsLink(art.URL) {
+			kept = append(kept, art)
+			continue
+		}
+
+		publisher, ok := f.cachedLink(art.URL)
+		if !ok && followed < maxGoogleNewsResolves && ctx.Err() == nil {
+			followed++
+			publisher, ok = f.followLink(ctx, cfg, art.URL)
+		}
+		if !ok {
+			dropped++
+			continue
+		}
+		resolved++
+		art.URL = publisher
+		art.ID = hashURL(publisher)
+		kept = append(kept, art)
+	}
+	return kept, resolved, dropped
+}
+
+// cachedLink returns the publisher URL a link was previously resolved to.
+func (f *googleNewsRSSFetcher) cachedLink(link string) (string, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	publisher, ok := f.resolved[link]
+	return publisher, ok
+}
+
+// followLink requests a news.google.com link without reading the body and returns the URL the redirects
+// end on, when that is a publisher page rather than another Google page.
+func (f *googleNewsRSSFetcher) followLink(ctx context.Context, cfg Provider, link string) (string, bool) {
+	target, headers := Authorize(cfg, link, Headers(cfg))
+	resp, err := f.client.Stream(ctx, httpclient.Request{Method: http.MethodGet, URL: target, Headers: headers})
+	if err != nil {
+		return "", false
+	}
+	resp.Close()
+
+	final := resp.URL()
+	if resp.StatusCode() >= http.StatusBadRequest || !isHTTPURL(final) || isGoogleHost(urlHost(final)) {
+		return "", false
+	}
+
+	f.mu.Lock()
+	if len(f.resolved) >= maxResolvedLinks {
+		clear(f.resolved)
+	}
+	f.resolved[link] = final
+	f.mu.Unlock()
+	return final, true
+}
+
+// isGoogleHost reports whether host is google.com or one of its subdomains (news, consent, ...).
+func isGoogleHost(host string) bool {
+	return host == "google.com" || strings.HasSuffix(host, ".google.com")
+}
+
+// googleNewsTitle strips the " - Outlet" suffix Google appends to headlines.
+func googleNewsTitle(title, outlet string) string {
+	title = strings.TrimSpace(title)
+	if outlet == "" {
+		return title
+	}
+	return strings.TrimSpace(strings.TrimSuffix(title, " - "+outlet))
+}
+
+// isGoogleNewsLink reports whether raw points at a news.google.com article redirect.
+func isGoogleNewsLink(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && strings.EqualFold(u.Host, "news.google.com") && strings.Contains(u.Path, "/articles/")
+}
+
+// decodeGoogleNewsLink recovers the publisher URL from a news.google.com/rss/articles/<id> link. The id is a
+// base64url-encoded protobuf message carrying the URL as a length-delimited field. Newer opaque ids that
+// only Google can resolve are reported as not ok.
+func decodeGoogleNewsLink(raw string) (string, bool) {
+	if !isGoogleNewsLink(raw) {
+		return "", false
+	}
+	u, _ := url.Parse(raw)
+	id := u.Path[strings.LastIndex(u.Path, "/")+1:]
+
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(id, "="))
+	if err != nil {
+		return "", false
+	}
+	for len(data) > 0 {
+		tag, n := binary.Uvarint(data)
+		if n <= 0 {
+			return "", false
+		}
+		data = data[n:]
+		switch tag & 0x7 {
+		case 0: // varint
+			if _, n = binary.Uvarint(data); n <= 0 {
+				return "", false
+			}
+			data = data[n:]
+		case 2: // length-delimited
+			size, n := binary.Uvarint(data)
+			if n <= 0 || size > uint64(len(data)-n) {
+				return "", false
+			}
+			value := string(data[n : n+int(size)])
+			data = data[n+int(size):]
+			if isHTTPURL(value) {
+				return value, true
+			}
+		default:
+			return "", false
+		}
+	}
+	return "", false
+}
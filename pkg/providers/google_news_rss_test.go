@@ -0,0 +1,153 @@
+package providers
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"net/http"
+	"testing"
+)
+
+// googleNewsLink encodes target the way Google News RSS article ids carry the publisher URL.
+func googleNewsLink(target string) string {
+	msg := binary.AppendUvarint([]byte{0x08, 0x13, 0x22}, uint64(len(target)))
+	msg = append(msg, target...)
+	msg = append(msg, 0xd2, 0x01, 0x00)
+	return "https://news.google.com/rss/articles/" + base64.RawURLEncoding.EncodeToString(msg) + "?oc=5"
+}
+
+func TestGoogleNewsRSSURL(t *testing.T) {
+	tests := []struct {
+		name   string
+		config map[string]any
+		want   string
+	}{
+		{
+			name:   "search",
+			config: map[string]any{ConfigQueryKey: "ISRO launch", ConfigLanguageKey: "en-IN", ConfigRegionKey: "in"},
+			want:   "https://news.google.com/rss/search?ceid=IN%3Aen&gl=IN&hl=en-IN&q=ISRO+launch",
+		},
+		{
+			name:   "section",
+			config: map[string]any{ConfigTopicKey: "science", ConfigLanguageKey: "hi", ConfigRegionKey: "IN"},
+			want:   "https://news.google.com/rss/headlines/section/topic/SCIENCE?ceid=IN%3Ahi&gl=IN&hl=hi",
+		},
+		{
+			name:   "topic id with defaults",
+			config: map[string]any{ConfigTopicKey: "CAAqJggKIiBDQkFTRWdvSUwyMHZNRGx1YlY4U0FtVnVHZ0pWVXlnQVAB"},
+			want:   "https://news.google.com/rss/topics/CAAqJggKIiBDQkFTRWdvSUwyMHZNRGx1YlY4U0FtVnVHZ0pWVXlnQVAB?ceid=US%3Aen&gl=US&hl=en-US",
+		},
+		{name: "unset", config: map[string]any{}, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GoogleNewsRSSURL(Provider{Config: tt.config}); got != tt.want {
+				t.Fatalf("GoogleNewsRSSURL = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeGoogleNewsLink(t *testing.T) {
+	long := "https://www.example.com/india/monsoon-arrives-in-kerala-three-days-early-imd-says-" +
+		"with-heavy-rain-forecast-for-the-coast-over-the-weekend-1234567.html"
+	for _, target := range []string{"https://example.com/a", long} {
+		got, ok := decodeGoogleNewsLink(googleNewsLink(target))
+		if !ok || got != target {
+			t.Fatalf("decode %q: got %q, %v", target, got, ok)
+		}
+	}
+
+	for _, raw := range []string{
+		"https://news.google.com/rss/articles/CBMiWkFVX3lxTE1hYmNkZWZnaGlqa2xtbm9wcXJzdHV2d3h5ejAxMjM0NTY3ODk?oc=5",
+		"https://example.com/rss/articles/abc",
+		"https://news.google.com/rss/articles/!!!",
+	} {
+		if got, ok := decodeGoogleNewsLink(raw); ok {
+			t.Fatalf("expected %q not to decode, got %q", raw, got)
+		}
+	}
+}
+
+func TestGoogleNewsRSSFetcherDecodesLinksAndOutlet(t *testing.T) {
+	opaque := "https://news.google.com/rss/articles/CBMiWkFVX3lxTE1hYmNkZWZnaGlqa2xtbm9wcXJzdHV2d3h5ejAxMjM0NTY3ODk?oc=5"
+	walled := "https://news.google.com/rss/articles/CBMiWkFVX3lxTE9wYXF1ZQ?oc=5"
+	feed := []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0"><channel>
+  <item>
+    <title>ISRO launches weather satellite - The Example Times</title>
+    <link>` + googleNewsLink("https://example.com/isro-launch") + `</link>
+    <guid isPermaLink="false">CBMi1</guid>
+    <pubDate>Fri, 01 Mar 2024 06:30:00 GMT</pubDate>
+    <source url="https://example.com">The Example Times</source>
+  </item>
+  <item>
+    <title>Monsoon update - Daily Other</title>
+    <link>` + opaque + `</link>
+    <source url="https://other.example">Daily Other</source>
+  </item>
+  <item>
+    <title>Consent wall - Walled</title>
+    <link>` + walled + `</link>
+  </item>
+</channel></rss>`)
+
+	cfg := sanitizeProvider(Provider{
+		ID:     "isro",
+		Type:   ProviderTypeGoogleNewsRSS,
+		Config: map[string]any{ConfigQueryKey: "ISRO", ConfigLanguageKey: "en-IN", ConfigRegionKey: "IN"},
+	})
+	client := &fakeHTTPClient{responses: map[string]fakeResponse{
+		cfg.SourceURL: {body: feed, statusCode: http.StatusOK},
+		opaque:        {statusCode: http.StatusOK, url: "https://other.example/monsoon"},
+		walled:        {statusCode: http.StatusOK, url: "https://consent.google.com/ml?continue=x"},
+	}}
+	fetcher := NewGoogleNewsRSSFetcher(client, nil)
+	articles, err := fetcher.Fetch(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if len(articles) != 2 {
+		t.Fatalf("expected 2 articles, got %d", len(articles))
+	}
+
+	first := articles[0]
+	if first.URL != "https://example.com/isro-launch" || first.ID != hashURL(first.URL) {
+		t.Fatalf("expected decoded publisher url, got %+v", first)
+	}
+	if first.Title != "ISRO launches weather satellite" || first.PublicationName != "The Example Times" {
+		t.Fatalf("unexpected title/outlet: %q / %q", first.Title, first.PublicationName)
+	}
+	if first.PublishedAt.IsZero() {
+		t.Fatalf("expected published_at to be parsed")
+	}
+	second := articles[1]
+	if second.URL != "https://other.example/monsoon" || second.ID != hashURL(second.URL) || second.PublicationName != "Daily Other" {
+		t.Fatalf("expected opaque link to be resolved by following it, got %+v", second)
+	}
+
+	stats := fetcher.(FetchStatsReporter).LastFetchStats("isro")
+	if stats[FetchStatRedirectsResolved] != 1 || stats[FetchStatRedirectsUndecoded] != 1 {
+		t.Fatalf("expected 1 resolved and 1 dropped redirect, got %v", stats)
+	}
+
+	// Resolved links are cached, so the next run does not follow them again.
+	delete(client.responses, opaque)
+	if articles, err = fetcher.Fetch(context.Background(), cfg); err != nil || len(articles) != 2 || articles[1].URL != "https://other.example/monsoon" {
+		t.Fatalf("expected cached resolution, got %+v err=%v", articles, err)
+	}
+}
+
+func TestValidateGoogleNewsRSSRequiresQueryOrTopic(t *testing.T) {
+	p := sanitizeProvider(Provider{ID: "g", Name: "G", Type: ProviderTypeGoogleNewsRSS, ResponseFormat: ResponseFormatAuto})
+	if err := validateProvider(p); err == nil {
+		t.Fatalf("expected validation error without query or topic")
+	}
+	p = sanitizeProvider(Provider{
+		ID: "g", Name: "G", Type: ProviderTypeGoogleNewsRSS, ResponseFormat: ResponseFormatAuto,
+		Config: map[string]any{ConfigTopicKey: "WORLD"},
+	})
+	if err := validateProvider(p); err != nil {
+		t.Fatalf("validateProvider: %v", err)
+	}
+}
@@ -0,0 +1,204 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/samvad-hq/samvad-news-harvester/internal/domain"
+	"github.com/samvad-hq/samvad-news-harvester/pkg/robots"
+)
+
+// Config keys describing how to extract articles from an HTML listing page.
+// Field selectors are evaluated relative to each item; an empty selector means the item itself,
+// and the title defaults to the link element's text.
+// An attribute of "text" reads the element's text content.
+const (
+	ConfigItemSelectorKey  = "item_selector"
+	ConfigLinkSelectorKey  = "link_selector"
+	ConfigLinkAttrKey      = "link_attr"
+	ConfigTitleSelectorKey = "title_selector"
+	ConfigTitleAttrKey     = "title_attr"
+	ConfigTimeSelectorKey  = "time_selector"
+	ConfigTimeAttrKey      = "time_attr"
+	ConfigImageSelectorKey = "image_selector"
+	ConfigImageAttrKey     = "image_attr"
+
+	htmlTextAttr = "text"
+)
+
+// htmlListingFetcher implements Fetcher for section/listing pages scraped with CSS selectors.
+type htmlListingFetcher struct {
+	client HTTPClient
+	validatorCache
+	fetchStats
+}
+
+// NewHTMLListingFetcher builds a Fetcher for HTML listing page providers.
+// validators is optional; when set, unchanged sources are skipped via conditional GETs.
+func NewHTMLListingFetcher(client HTTPClient, validators ValidatorStore) Fetcher {
+	if client == nil {
+		client = DefaultHTTPClient()
+	}
+	return &htmlListingFetcher{client: client, validatorCache: validatorCache{store: validators}}
+}
+
+// ID returns the provider type for the HTML listing fetcher.
+func (f *htmlListingFetcher) ID() string {
+	return ProviderTypeHTMLListing
+}
+
+// Fetch retrieves articles from an HTML listing page provider.
+func (f *htmlListingFetcher) Fetch(ctx context.Context, cfg Provider) ([]domain.Article, error) {
+	if !strings.EqualFold(cfg.Type, ProviderTypeHTMLListing) {
+		return nil, fmt.Errorf("html listing fetcher received incompatible provider type %q", cfg.Type)
+	}
+	if strings.TrimSpace(cfg.SourceURL) == "" {
+		return nil, fmt.Errorf("provider %q source_url is empty", cfg.ID)
+	}
+
+	headers := Headers(cfg)
+	doc, err := fetchDocument(ctx, f.client, cfg.SourceURL, cfg, "listing page", ResponseFormatHTML, conditionalHeaders(f.store, cfg.SourceURL, headers))
+	if err != nil {
+		return nil, err
+	}
+	if doc.NotModified {
+		f.holdSource(cfg.ID, cfg.SourceURL, doc.Validators)
+		return nil, nil
+	}
+	if robots.ParseDirectives(headers["User-Agent"], doc.Header.Values("X-Robots-Tag")...).NoFollow {
+		return nil, fmt.Errorf("%s listing page is marked nofollow", cfg.ID)
+	}
+
+	dates := newDateParser(cfg)
+	articles, err := parseHTMLListing(cfg, doc.Body, dates)
+	f.record(cfg.ID, map[string]int{FetchStatDateParseFailures: dates.failures})
+	if err != nil {
+		return nil, err
+	}
+	if len(articles) == 0 {
+		return nil, fmt.Errorf("%s listing page returned no records", cfg.ID)
+	}
+	f.holdSource(cfg.ID, cfg.SourceURL, doc.Validators)
+	return articles, nil
+}
+
+// parseHTMLListing applies the configured selectors to the page and builds articles.
+// Pages whose meta robots tag says nofollow are rejected rather than harvested.
+func parseHTMLListing(cfg Provider, data []byte, dates *dateParser) ([]domain.Article, error) {
+	itemSel := ConfigString(cfg, ConfigItemSelectorKey, "")
+	if itemSel == "" {
+		return nil, fmt.Errorf("provider %q config %s is required", cfg.ID, ConfigItemSelectorKey)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("parse listing html: %w", err)
+	}
+
+	metaRobots, _ := doc.Find(`meta[name="robots" i]`).First().Attr("content")
+	if robots.ParseDirectives(ConfigString(cfg, ConfigUserAgentKey, ""), metaRobots).NoFollow {
+		return nil, fmt.Errorf("%s listing page is marked nofollow", cfg.ID)
+	}
+
+	base := cfg.SourceURL
+	if href, ok := doc.Find("base[href]").First().Attr("href"); ok {
+		base = resolveReference(href, cfg.SourceURL)
+	}
+
+	linkSel := ConfigString(cfg, ConfigLinkSelectorKey, "")
+	linkAttr := ConfigString(cfg, ConfigLinkAttrKey, "href")
+	titleSel := ConfigString(cfg, ConfigTitleSelectorKey, linkSel)
+	titleAttr := ConfigString(cfg, ConfigTitleAttrKey, htmlTextAttr)
+	timeSel := ConfigString(cfg, ConfigTimeSelectorKey, "")
+	timeAttr := ConfigString(cfg, ConfigTimeAttrKey, "datetime")
+	imageSel := ConfigString(cfg, ConfigImageSelectorKey, "")
+	imageAttr := ConfigString(cfg, ConfigImageAttrKey, "src")
+
+	seen := make(map[string]struct{})
+	var articles []domain.Article
+	doc.Find(itemSel).Each(func(_ int, item *goquery.Selection) {
+		link := resolveReference(selectValue(item, linkSel, linkAttr), base)
+		if link == "" {
+			return
+		}
+		if _, dup := seen[link]; dup {
+			return
+		}
+		seen[link] = struct{}{}
+
+		title := selectValue(item, titleSel, titleAttr)
+
+		var image string
+		if imageSel != "" {
+			image = resolveReference(firstNonBlank(
+				selectValue(item, imageSel, imageAttr),
+				selectValue(item, imageSel, "data-src"),
+			), base)
+		}
+
+		var published string
+		if timeSel != "" {
+			published = firstNonBlank(selectValue(item, timeSel, timeAttr), selectValue(item, timeSel, htmlTextAttr))
+		}
+
+		articles = append(articles, domain.Article{
+			ProviderID:  cfg.ID,
+			ID:          hashURL(link),
+			Title:       collapseSpaces(title),
+			URL:         link,
+			ImageURL:    image,
+			PublishedAt: dates.parse(published),
+		})
+	})
+	return articles, nil
+}
+
+// selectValue reads attr (or text) from the first match of sel within item; an empty sel targets item.
+func selectValue(item *goquery.Selection, sel, attr string) string {
+	node := item
+	if sel != "" {
+		node = item.Find(sel).First()
+	}
+	if node.Length() == 0 {
+		return ""
+	}
+	if attr == htmlTextAttr {
+		return strings.TrimSpace(node.Text())
+	}
+	val, _ := node.Attr(attr)
+	return strings.TrimSpace(val)
+}
+
+// collapseSpaces replaces runs of whitespace with a single space.
+func collapseSpaces(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// resolveReference resolves a possibly relative URL against base, returning "" for blank or non-http links.
+func resolveReference(raw, base string) string {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return ""
+	}
+
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	if !parsed.IsAbs() {
+		baseURL, err := url.Parse(base)
+		if err != nil {
+			return ""
+		}
+		parsed = baseURL.ResolveReference(parsed)
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return ""
+	}
+	parsed.Fragment = ""
+	return parsed.String()
+}
@@ -0,0 +1,118 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseHTMLListing(t *testing.T) {
+	page := []byte(`
+<html>
+  <body>
+    <ul class="stories">
+      <li class="story">
+        <a class="headline" href="/india/story-one#comments">  Story
+          one </a>
+        <time datetime="2024-04-01T09:00:00Z">1 Apr</time>
+        <img data-src="/img/one.jpg">
+      </li>
+      <li class="story">
+        <a class="headline" href="https://cdn.example.org/two">Story two</a>
+        <img src="//img.example.com/two.jpg">
+      </li>
+      <li class="story">
+        <a class="headline" href="/india/story-one">Duplicate</a>
+      </li>
+      <li class="story">
+        <a class="headline" href="javascript:void(0)">Ad</a>
+      </li>
+    </ul>
+  </body>
+</html>`)
+
+	cfg := Provider{
+		ID:        "listing",
+		SourceURL: "https://example.com/section/india",
+		Config: map[string]any{
+			ConfigItemSelectorKey:  "li.story",
+			ConfigLinkSelectorKey:  "a.headline",
+			ConfigTimeSelectorKey:  "time",
+			ConfigImageSelectorKey: "img",
+		},
+	}
+
+	articles, err := parseHTMLListing(cfg, page, newDateParser(cfg))
+	if err != nil {
+		t.Fatalf("parseHTMLListing: %v", err)
+	}
+	if len(articles) != 2 {
+		t.Fatalf("expected 2 articles, got %d: %#v", len(articles), articles)
+	}
+
+	first := articles[0]
+	if first.URL != "https://example.com/india/story-one" {
+		t.Errorf("URL = %s", first.URL)
+	}
+	if first.Title != "Story one" {
+		t.Errorf("Title = %q", first.Title)
+	}
+	if first.ImageURL != "https://example.com/img/one.jpg" {
+		t.Errorf("ImageURL = %s", first.ImageURL)
+	}
+	if !first.PublishedAt.Equal(time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("PublishedAt = %v", first.PublishedAt)
+	}
+	if articles[1].ImageURL != "https://img.example.com/two.jpg" {
+		t.Errorf("expected protocol-relative image resolution, got %s", articles[1].ImageURL)
+	}
+}
+
+func TestHTMLListingFetcherRequiresItemSelector(t *testing.T) {
+	client := &fakeHTTPClient{
+		responses: map[string]fakeResponse{
+			"https://example.com/": {body: []byte("<html></html>"), statusCode: http.StatusOK},
+		},
+	}
+	_, err := NewHTMLListingFetcher(client, nil).Fetch(context.Background(), Provider{
+		ID:        "listing",
+		Type:      ProviderTypeHTMLListing,
+		SourceURL: "https://example.com/",
+	})
+	if err == nil {
+		t.Fatalf("expected error when item_selector is missing")
+	}
+}
+
+func TestHTMLListingFetcherHonorsNoFollow(t *testing.T) {
+	page := `<html><head>%s</head><body><a class="story" href="/a">A</a></body></html>`
+	cases := map[string]fakeResponse{
+		"meta": {
+			body:       []byte(fmt.Sprintf(page, `<meta name="robots" content="nofollow">`)),
+			statusCode: http.StatusOK,
+		},
+		"header": {
+			body:       []byte(fmt.Sprintf(page, "")),
+			statusCode: http.StatusOK,
+			header:     http.Header{"X-Robots-Tag": []string{"noindex, nofollow"}},
+		},
+	}
+
+	for name, resp := range cases {
+		t.Run(name, func(t *testing.T) {
+			client := &fakeHTTPClient{responses: map[string]fakeResponse{"https://example.com/": resp}}
+			_, err := NewHTMLListingFetcher(client, nil).Fetch(context.Background(), Provider{
+				ID:        "listing",
+				Type:      ProviderTypeHTMLListing,
+				SourceURL: "https://example.com/",
+				Config:    map[string]any{ConfigItemSelectorKey: "a.story"},
+			})
+			if err == nil || !strings.Contains(err.Error(), "nofollow") {
+				t.Fatalf("expected nofollow error, got %v", err)
+			}
+		})
+	}
+}
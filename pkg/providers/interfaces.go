@@ -19,5 +19,32 @@ type FetcherRegistry interface {
 	FetcherFor(cfg Provider) (Fetcher, error)
 }
 
+// ValidatorStore persists HTTP cache validators (ETag, Last-Modified) per source URL for conditional GETs.
+type ValidatorStore interface {
+	SourceValidators(url string) (domain.SourceValidators, error)
+	SaveSourceValidators(url string, v domain.SourceValidators) error
+}
+
+// DiscoveryStore persists the sitemaps found for sitemap_discovery providers so a restart does not repeat
+// discovery. A ValidatorStore that also implements it is used by the sitemap discovery fetcher.
+type DiscoveryStore interface {
+	DiscoveredSitemaps(providerID string) (domain.DiscoveredSitemaps, error)
+	// SaveDiscoveredSitemaps stores d for the provider; a d without sitemaps removes the stored result.
+	SaveDiscoveredSitemaps(providerID string, d domain.DiscoveredSitemaps) error
+}
+
 // HTTPClient aliases the shared httpclient.Client interface for clarity within providers.
 type HTTPClient = httpclient.Client
+
+// FetchStatsReporter is implemented by fetchers that keep counters (e.g. namespace fallbacks) about their
+// most recent run for each provider.
+type FetchStatsReporter interface {
+	LastFetchStats(providerID string) map[string]int
+}
+
+// ValidatorCommitter is implemented by fetchers that hold back the cache validators seen during a run.
+// Callers commit them once the run's articles have been delivered, so a run that failed downstream is
+// fetched in full next time instead of being answered with 304 Not Modified.
+type ValidatorCommitter interface {
+	CommitValidators(providerID string)
+}
@@ -0,0 +1,262 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/samvad-hq/samvad-news-harvester/internal/domain"
+)
+
+// Config keys describing where article fields live inside a JSON API response.
+// Paths are dot-separated object keys; numeric segments index into arrays (e.g. "images.0.url").
+const (
+	ConfigItemsPathKey       = "items_path"
+	ConfigURLPathKey         = "url_path"
+	ConfigTitlePathKey       = "title_path"
+	ConfigDescriptionPathKey = "description_path"
+	ConfigImagePathKey       = "image_path"
+	ConfigPublishedPathKey   = "published_path"
+	ConfigKeywordsPathKey    = "keywords_path"
+
+	jsonFeedVersionPrefix = "https://jsonfeed.org/version/"
+)
+
+// jsonFieldMap lists the paths used to build articles from JSON items.
+type jsonFieldMap struct {
+	Items       string
+	URL         string
+	Title       string
+	Description string
+	Image       string
+	Published   string
+	Keywords    []string
+}
+
+// jsonFeedFields maps JSON Feed 1.x items; earlier entries win when several paths are set.
+var jsonFeedFields = jsonFieldMap{
+	Items:       "items",
+	URL:         "url|external_url",
+	Title:       "title",
+	Description: "summary|content_text",
+	Image:       "image|banner_image",
+	Published:   "date_published|date_modified",
+	Keywords:    []string{"tags"},
+}
+
+// jsonAPIFetcher implements Fetcher for JSON endpoints described by field paths in provider config.
+type jsonAPIFetcher struct {
+	client HTTPClient
+	validatorCache
+	fetchStats
+}
+
+// NewJSONAPIFetcher builds a Fetcher for configurable JSON API providers.
+// validators is optional; when set, unchanged sources are skipped via conditional GETs.
+func NewJSONAPIFetcher(client HTTPClient, validators ValidatorStore) Fetcher {
+	if client == nil {
+		client = DefaultHTTPClient()
+	}
+	return &jsonAPIFetcher{client: client, validatorCache: validatorCache{store: validators}}
+}
+
+// ID returns the provider type for the JSON API fetcher.
+func (f *jsonAPIFetcher) ID() string {
+	return ProviderTypeJSONAPI
+}
+
+// Fetch retrieves articles from a JSON API or JSON Feed provider.
+func (f *jsonAPIFetcher) Fetch(ctx context.Context, cfg Provider) ([]domain.Article, error) {
+	if !strings.EqualFold(cfg.Type, ProviderTypeJSONAPI) {
+		return nil, fmt.Errorf("json api fetcher received incompatible provider type %q", cfg.Type)
+	}
+	if strings.TrimSpace(cfg.SourceURL) == "" {
+		return nil, fmt.Errorf("provider %q source_url is empty", cfg.ID)
+	}
+
+	doc, err := fetchDocument(ctx, f.client, cfg.SourceURL, cfg, "api", ResponseFormatJSON, conditionalHeaders(f.store, cfg.SourceURL, Headers(cfg)))
+	if err != nil {
+		return nil, err
+	}
+	if doc.NotModified {
+		f.holdSource(cfg.ID, cfg.SourceURL, doc.Validators)
+		return nil, nil
+	}
+
+	dates := newDateParser(cfg)
+	articles, err := parseJSONArticles(cfg, doc.Body, dates)
+	f.record(cfg.ID, map[string]int{FetchStatDateParseFailures: dates.failures})
+	if err != nil {
+		return nil, err
+	}
+	if len(articles) == 0 {
+		return nil, fmt.Errorf("%s api returned no records", cfg.ID)
+	}
+	f.holdSource(cfg.ID, cfg.SourceURL, doc.Validators)
+	return articles, nil
+}
+
+// parseJSONArticles decodes the payload and maps its items to articles using the configured or JSON Feed field map.
+func parseJSONArticles(cfg Provider, data []byte, dates *dateParser) ([]domain.Article, error) {
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("decode json api: %w", err)
+	}
+
+	fields := jsonFieldsFromConfig(cfg)
+	if isJSONFeed(doc) && fields.URL == "" {
+		fields = jsonFeedFields
+	}
+	if fields.URL == "" {
+		return nil, fmt.Errorf("provider %q config %s is required", cfg.ID, ConfigURLPathKey)
+	}
+
+	rawItems := doc
+	if fields.Items != "" {
+		rawItems = lookupJSONPath(doc, fields.Items)
+	}
+	items, ok := rawItems.([]any)
+	if !ok {
+		return nil, fmt.Errorf("provider %q items path %q does not point to an array", cfg.ID, fields.Items)
+	}
+
+	articles := make([]domain.Article, 0, len(items))
+	for _, item := range items {
+		// Relative links are resolved against the endpoint; anything that is not http(s) is skipped.
+		loc := resolveReference(jsonString(item, fields.URL), cfg.SourceURL)
+		if loc == "" {
+			continue
+		}
+
+		var keywords []string
+		for _, path := range fields.Keywords {
+			keywords = append(keywords, jsonStrings(item, path)...)
+		}
+
+		articles = append(articles, domain.Article{
+			ProviderID:  cfg.ID,
+			ID:          hashURL(loc),
+			Title:       jsonString(item, fields.Title),
+			URL:         loc,
+			Description: jsonString(item, fields.Description),
+			ImageURL:    resolveReference(jsonString(item, fields.Image), cfg.SourceURL),
+			Keywords:    trimmedValues(keywords),
+			PublishedAt: jsonTime(item, fields.Published, dates),
+		})
+	}
+	return articles, nil
+}
+
+// jsonFieldsFromConfig reads the field paths from provider config.
+func jsonFieldsFromConfig(cfg Provider) jsonFieldMap {
+	fields := jsonFieldMap{
+		Items:       ConfigString(cfg, ConfigItemsPathKey, ""),
+		URL:         ConfigString(cfg, ConfigURLPathKey, ""),
+		Title:       ConfigString(cfg, ConfigTitlePathKey, ""),
+		Description: ConfigString(cfg, ConfigDescriptionPathKey, ""),
+		Image:       ConfigString(cfg, ConfigImagePathKey, ""),
+		Published:   ConfigString(cfg, ConfigPublishedPathKey, ""),
+	}
+	if kw := ConfigString(cfg, ConfigKeywordsPathKey, ""); kw != "" {
+		fields.Keywords = []string{kw}
+	}
+	return fields
+}
+
+// isJSONFeed reports whether the document declares a JSON Feed version.
+func isJSONFeed(doc any) bool {
+	obj, ok := doc.(map[string]any)
+	if !ok {
+		return false
+	}
+	version, _ := obj["version"].(string)
+	return strings.HasPrefix(version, jsonFeedVersionPrefix)
+}
+
+// lookupJSONPath walks a dot-separated path through decoded JSON. Alternatives separated by "|" are tried in order.
+func lookupJSONPath(doc any, path string) any {
+	for _, alt := range strings.Split(path, "|") {
+		if v := walkJSONPath(doc, strings.TrimSpace(alt)); v != nil {
+			return v
+		}
+	}
+	return nil
+}
+
+// walkJSONPath resolves a single dot-separated path.
+func walkJSONPath(doc any, path string) any {
+	if path == "" {
+		return nil
+	}
+
+	cur := doc
+	for _, seg := range strings.Split(path, ".") {
+		switch node := cur.(type) {
+		case map[string]any:
+			cur = node[seg]
+		case []any:
+			idx, err := strconv.Atoi(seg)
+			if err != nil || idx < 0 || idx >= len(node) {
+				return nil
+			}
+			cur = node[idx]
+		default:
+			return nil
+		}
+		if cur == nil {
+			return nil
+		}
+	}
+	return cur
+}
+
+// jsonString returns the value at path as a trimmed string, or "" when absent or not scalar.
+func jsonString(doc any, path string) string {
+	switch v := lookupJSONPath(doc, path).(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return ""
+	}
+}
+
+// jsonStrings returns the value at path as a list, accepting arrays of strings or a comma-separated string.
+func jsonStrings(doc any, path string) []string {
+	switch v := lookupJSONPath(doc, path).(type) {
+	case string:
+		return parseKeywords(v)
+	case []any:
+		out := make([]string, 0, len(v))
+		for _, el := range v {
+			if s, ok := el.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	default:
+		return nil
+	}
+}
+
+// jsonTime parses the value at path as a date string or a unix timestamp in seconds or milliseconds.
+func jsonTime(doc any, path string, dates *dateParser) time.Time {
+	switch v := lookupJSONPath(doc, path).(type) {
+	case string:
+		return dates.parse(v)
+	case float64:
+		if v <= 0 {
+			return time.Time{}
+		}
+		if v > 1e12 {
+			return time.UnixMilli(int64(v)).UTC()
+		}
+		return time.Unix(int64(v), 0).UTC()
+	default:
+		return time.Time{}
+	}
+}
@@ -0,0 +1,153 @@
+package providers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestParseJSONArticlesWithFieldMap(t *testing.T) {
+	cfg := Provider{
+		ID: "api",
+		Config: map[string]any{
+			ConfigItemsPathKey:       "data.stories",
+			ConfigURLPathKey:         "links.web",
+			ConfigTitlePathKey:       "headline",
+			ConfigDescriptionPathKey: "summary",
+			ConfigImagePathKey:       "images.0.src",
+			ConfigPublishedPathKey:   "published",
+			ConfigKeywordsPathKey:    "tags",
+		},
+	}
+	data := []byte(`{
+  "data": {
+    "stories": [
+      {
+        "headline": " Monsoon arrives ",
+        "summary": "Early onset",
+        "links": {"web": "https://example.com/monsoon"},
+        "images": [{"src": "https://example.com/m.jpg"}],
+        "published": "2024-06-01T06:00:00Z",
+        "tags": ["weather", "kerala"]
+      },
+      {
+        "headline": "Epoch millis",
+        "links": {"web": "https://example.com/epoch"},
+        "published": 1717221600000,
+        "tags": "a, b"
+      },
+      {"headline": "missing link"}
+    ]
+  }
+}`)
+
+	articles, err := parseJSONArticles(cfg, data, newDateParser(cfg))
+	if err != nil {
+		t.Fatalf("parseJSONArticles: %v", err)
+	}
+	if len(articles) != 2 {
+		t.Fatalf("expected 2 articles, got %d", len(articles))
+	}
+
+	art := articles[0]
+	if art.URL != "https://example.com/monsoon" || art.Title != "Monsoon arrives" || art.Description != "Early onset" {
+		t.Errorf("unexpected article %+v", art)
+	}
+	if art.ImageURL != "https://example.com/m.jpg" {
+		t.Errorf("ImageURL = %s", art.ImageURL)
+	}
+	if len(art.Keywords) != 2 || art.Keywords[1] != "kerala" {
+		t.Errorf("Keywords = %#v", art.Keywords)
+	}
+	if !art.PublishedAt.Equal(time.Date(2024, 6, 1, 6, 0, 0, 0, time.UTC)) {
+		t.Errorf("PublishedAt = %v", art.PublishedAt)
+	}
+
+	if got := articles[1].PublishedAt; !got.Equal(time.UnixMilli(1717221600000)) {
+		t.Errorf("epoch PublishedAt = %v", got)
+	}
+	if len(articles[1].Keywords) != 2 {
+		t.Errorf("expected comma-separated keywords, got %#v", articles[1].Keywords)
+	}
+}
+
+func TestParseJSONArticlesResolvesLinks(t *testing.T) {
+	cfg := Provider{
+		ID:        "api",
+		SourceURL: "https://api.example.com/v1/stories",
+		Config: map[string]any{
+			ConfigURLPathKey:   "url",
+			ConfigImagePathKey: "image",
+		},
+	}
+	data := []byte(`[
+  {"url": "/news/relative", "image": "img/r.jpg"},
+  {"url": "javascript:void(0)"},
+  {"url": "mailto:desk@example.com"},
+  {"url": "https://example.com/absolute"}
+]`)
+
+	articles, err := parseJSONArticles(cfg, data, newDateParser(cfg))
+	if err != nil {
+		t.Fatalf("parseJSONArticles: %v", err)
+	}
+	if len(articles) != 2 {
+		t.Fatalf("expected non-http links to be skipped, got %+v", articles)
+	}
+	if art := articles[0]; art.URL != "https://api.example.com/news/relative" || art.ID != hashURL(art.URL) || art.ImageURL != "https://api.example.com/v1/img/r.jpg" {
+		t.Fatalf("expected links resolved against source_url, got %+v", art)
+	}
+	if articles[1].URL != "https://example.com/absolute" {
+		t.Fatalf("unexpected absolute link %q", articles[1].URL)
+	}
+}
+
+func TestParseJSONArticlesRequiresArray(t *testing.T) {
+	cfg := Provider{ID: "api", Config: map[string]any{ConfigItemsPathKey: "data", ConfigURLPathKey: "url"}}
+	if _, err := parseJSONArticles(cfg, []byte(`{"data": {"url": "x"}}`), newDateParser(cfg)); err == nil {
+		t.Fatalf("expected error when items path is not an array")
+	}
+}
+
+func TestJSONAPIFetcherHandlesJSONFeed(t *testing.T) {
+	feed := []byte(`{
+  "version": "https://jsonfeed.org/version/1.1",
+  "title": "Example",
+  "items": [
+    {
+      "id": "1",
+      "external_url": "https://example.com/ext",
+      "title": "From JSON Feed",
+      "content_text": "Body",
+      "banner_image": "https://example.com/banner.png",
+      "date_published": "2024-05-01T12:00:00+05:30",
+      "tags": ["space"]
+    }
+  ]
+}`)
+	client := &fakeHTTPClient{
+		responses: map[string]fakeResponse{
+			"https://example.com/feed.json": {body: feed, statusCode: http.StatusOK},
+		},
+	}
+
+	articles, err := NewJSONAPIFetcher(client, nil).Fetch(context.Background(), Provider{
+		ID:        "jf",
+		Type:      ProviderTypeJSONAPI,
+		SourceURL: "https://example.com/feed.json",
+	})
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if len(articles) != 1 {
+		t.Fatalf("expected 1 article, got %d", len(articles))
+	}
+	art := articles[0]
+	if art.URL != "https://example.com/ext" || art.Description != "Body" || art.ImageURL != "https://example.com/banner.png" {
+		t.Errorf("unexpected article %+v", art)
+	}
+	if !art.PublishedAt.Equal(time.Date(2024, 5, 1, 6, 30, 0, 0, time.UTC)) {
+		t.Errorf("PublishedAt = %v", art.PublishedAt)
+	}
+}
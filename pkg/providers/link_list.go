@@ -0,0 +1,199 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/samvad-hq/samvad-news-harvester/internal/domain"
+)
+
+// maxLinkListBytes caps how much of a local link list is read.
+const maxLinkListBytes = 16 << 20 // 16 MiB
+
+// linkListFetcher implements Fetcher for hand-curated lists of article URLs.
+type linkListFetcher struct {
+	client HTTPClient
+	validatorCache
+	fetchStats
+}
+
+// NewLinkListFetcher builds a Fetcher for link_list providers. Sources may be http(s) URLs or file:// paths.
+// validators is optional; when set, unchanged remote lists are skipped via conditional GETs.
+func NewLinkListFetcher(client HTTPClient, validators ValidatorStore) Fetcher {
+	if client == nil {
+		client = DefaultHTTPClient()
+	}
+	return &linkListFetcher{client: client, validatorCache: validatorCache{store: validators}}
+}
+
+// ID returns the provider type for the link list fetcher.
+func (f *linkListFetcher) ID() string {
+	return ProviderTypeLinkList
+}
+
+// Fetch reads the provider's link list and returns one article per listed URL.
+func (f *linkListFetcher) Fetch(ctx context.Context, cfg Provider) ([]domain.Article, error) {
+	if !strings.EqualFold(cfg.Type, ProviderTypeLinkList) {
+		return nil, fmt.Errorf("link list fetcher received incompatible provider type %q", cfg.Type)
+	}
+	if strings.TrimSpace(cfg.SourceURL) == "" {
+		return nil, fmt.Errorf("provider %q source_url is empty", cfg.ID)
+	}
+
+	var doc document
+	var err error
+	if isFileURL(cfg.SourceURL) {
+		doc.Body, err = readLocalSource(cfg.SourceURL)
+		if err != nil {
+			return nil, fmt.Errorf("read %s link list: %w", cfg.ID, err)
+		}
+	} else {
+		doc, err = f.fetchRemote(ctx, cfg)
+		if err != nil {
+			return nil, err
+		}
+		if doc.NotModified {
+			f.holdSource(cfg.ID, cfg.SourceURL, doc.Validators)
+			return nil, nil
+		}
+	}
+
+	dates := newDateParser(cfg)
+	articles, err := parseLinkList(cfg.ID, doc.Body, dates)
+	if err != nil {
+		return nil, fmt.Errorf("decode %s link list: %w", cfg.ID, err)
+	}
+	f.record(cfg.ID, map[string]int{FetchStatDateParseFailures: dates.failures})
+	if len(articles) == 0 {
+		return nil, fmt.Errorf("%s link list returned no records", cfg.ID)
+	}
+	f.holdSource(cfg.ID, cfg.SourceURL, doc.Validators)
+	return articles, nil
+}
+
+// fetchRemote downloads the link list over HTTP, inflating gzip bodies.
+func (f *linkListFetcher) fetchRemote(ctx context.Context, cfg Provider) (document, error) {
+	headers := conditionalHeaders(f.store, cfg.SourceURL, Headers(cfg))
+	target, headers := Authorize(cfg, cfg.SourceURL, headers)
+	resp, err := f.client.Get(ctx, target, headers)
+	if err != nil {
+		return document{}, fmt.Errorf("fetch %s link list: %w", cfg.ID, RedactError(cfg, err))
+	}
+	if resp.StatusCode() == http.StatusNotModified {
+		return document{NotModified: true, Validators: requestValidators(headers)}, nil
+	}
+
+	body := resp.Body()
+	if resp.StatusCode() != http.StatusOK {
+		return document{}, fmt.Errorf("%s link list returned status %d body: %s", cfg.ID, resp.StatusCode(), responseSnippet(body))
+	}
+	if bytes.HasPrefix(body, gzipMagic) {
+		if body, err = gunzip(body); err != nil {
+			return document{}, fmt.Errorf("decode %s link list: %w", cfg.ID, err)
+		}
+	}
+	return document{Body: body, Validators: responseValidators(resp.Header()), Header: resp.Header()}, nil
+}
+
+// parseLinkList reads one URL per line, optionally followed by CSV title and published_at columns.
+// Blank lines, # comments, a header row and rows whose first column is not an http(s) URL are skipped;
+// repeated URLs keep their first row.
+func parseLinkList(providerID string, data []byte, dates *dateParser) ([]domain.Article, error) {
+	r := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	r.Comment = '#'
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+	r.LazyQuotes = true
+
+	var articles []domain.Article
+	seen := make(map[string]struct{})
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		link := strings.TrimSpace(record[0])
+		if !isHTTPURL(link) {
+			continue
+		}
+		if _, dup := seen[link]; dup {
+			continue
+		}
+		seen[link] = struct{}{}
+
+		art := domain.Article{
+			ProviderID: providerID,
+			ID:         hashURL(link),
+			URL:        link,
+		}
+		if len(record) > 1 {
+			art.Title = strings.TrimSpace(record[1])
+		}
+		if len(record) > 2 {
+			art.PublishedAt = dates.parse(record[2])
+		}
+		articles = append(articles, art)
+	}
+	return articles, nil
+}
+
+// isHTTPURL reports whether raw is an absolute http(s) URL.
+func isHTTPURL(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	return u.Scheme == "http" || u.Scheme == "https"
+}
+
+// isFileURL reports whether raw uses the file:// scheme.
+func isFileURL(raw string) bool {
+	return strings.HasPrefix(strings.ToLower(strings.TrimSpace(raw)), "file://")
+}
+
+// readLocalSource reads a file:// source. file:///abs/path is absolute; file://relative/path is resolved
+// against the working directory. Gzip files are inflated.
+func readLocalSource(raw string) ([]byte, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return nil, err
+	}
+	path := u.Path
+	if u.Host != "" && u.Host != "localhost" {
+		path = u.Host + u.Path
+	}
+	if path == "" {
+		return nil, fmt.Errorf("%q has no path", raw)
+	}
+
+	file, err := os.Open(filepath.FromSlash(path))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxLinkListBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxLinkListBytes {
+		return nil, fmt.Errorf("%s exceeds %d bytes", path, maxLinkListBytes)
+	}
+	if bytes.HasPrefix(data, gzipMagic) {
+		return gunzip(data)
+	}
+	return data, nil
+}
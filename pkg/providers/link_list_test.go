@@ -0,0 +1,88 @@
+package providers
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLinkListFetcherReadsCSVOverHTTP(t *testing.T) {
+	body := []byte(`url,title,published_at
+https://example.com/a,"Budget, explained",2024-03-01 09:30
+# editors: keep newest first
+https://example.com/b
+
+https://example.com/a,Duplicate row,2024-03-02
+not-a-url,Ignored
+https://example.com/c,Undated,someday
+`)
+	client := &fakeHTTPClient{responses: map[string]fakeResponse{
+		"https://example.com/links.csv": {body: body, statusCode: http.StatusOK},
+	}}
+	fetcher := NewLinkListFetcher(client, nil)
+	articles, err := fetcher.Fetch(context.Background(), Provider{
+		ID:        "curated",
+		Type:      ProviderTypeLinkList,
+		SourceURL: "https://example.com/links.csv",
+		Config:    map[string]any{ConfigTimezoneKey: "Asia/Kolkata"},
+	})
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if len(articles) != 3 {
+		t.Fatalf("expected 3 articles, got %d: %+v", len(articles), articles)
+	}
+
+	first := articles[0]
+	if first.URL != "https://example.com/a" || first.Title != "Budget, explained" || first.ID != hashURL(first.URL) {
+		t.Fatalf("unexpected first article: %+v", first)
+	}
+	if want := time.Date(2024, 3, 1, 4, 0, 0, 0, time.UTC); !first.PublishedAt.Equal(want) {
+		t.Fatalf("expected published_at %v, got %v", want, first.PublishedAt)
+	}
+	if articles[1].URL != "https://example.com/b" || articles[1].Title != "" || !articles[1].PublishedAt.IsZero() {
+		t.Fatalf("unexpected bare url article: %+v", articles[1])
+	}
+
+	stats := fetcher.(FetchStatsReporter).LastFetchStats("curated")
+	if stats[FetchStatDateParseFailures] != 1 {
+		t.Fatalf("expected 1 date parse failure, got %v", stats)
+	}
+}
+
+func TestLinkListFetcherReadsFileSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "links.txt")
+	if err := os.WriteFile(path, []byte("https://example.com/one\nhttps://example.com/two\n"), 0o600); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+
+	client := &fakeHTTPClient{}
+	articles, err := NewLinkListFetcher(client, nil).Fetch(context.Background(), Provider{
+		ID:        "offline",
+		Type:      ProviderTypeLinkList,
+		SourceURL: "file://" + filepath.ToSlash(path),
+	})
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if len(articles) != 2 || articles[1].URL != "https://example.com/two" {
+		t.Fatalf("unexpected articles: %+v", articles)
+	}
+	if len(client.calls) != 0 {
+		t.Fatalf("expected no HTTP calls for a file source, got %v", client.calls)
+	}
+}
+
+func TestLinkListFetcherMissingFile(t *testing.T) {
+	_, err := NewLinkListFetcher(&fakeHTTPClient{}, nil).Fetch(context.Background(), Provider{
+		ID:        "offline",
+		Type:      ProviderTypeLinkList,
+		SourceURL: "file://" + filepath.ToSlash(filepath.Join(t.TempDir(), "missing.txt")),
+	})
+	if err == nil {
+		t.Fatalf("expected error for missing file")
+	}
+}
@@ -0,0 +1,69 @@
+package providers
+
+import "strings"
+
+// Namespace handling for the Google News and image sitemap extensions.
+const (
+	// ConfigNamespaceModeKey selects how strictly news:/image: namespaces are checked (lenient or strict).
+	ConfigNamespaceModeKey = "namespace_mode"
+
+	// NamespaceModeLenient matches news:/image: elements by local name whatever their namespace.
+	NamespaceModeLenient = "lenient"
+	// NamespaceModeStrict accepts only the canonical namespaces and their known variants.
+	NamespaceModeStrict = "strict"
+
+	googleNewsNamespace  = "http://www.google.com/schemas/sitemap-news/0.9"
+	googleImageNamespace = "http://www.google.com/schemas/sitemap-image/1.1"
+
+	// FetchStatNamespaceFallbacks counts entries whose news/image elements used a non-canonical namespace.
+	FetchStatNamespaceFallbacks = "namespace_fallbacks"
+)
+
+// applyNamespaceMode enforces the provider's namespace mode on decoded entries in place and returns how many
+// entries relied on the fallback (a namespace variant, a wrong namespace or none at all). In strict mode,
+// extensions in unknown namespaces are dropped, as the original exact-namespace parsing did.
+func applyNamespaceMode(cfg Provider, urls []googleNewsURL) int {
+	strict := strings.EqualFold(ConfigString(cfg, ConfigNamespaceModeKey, NamespaceModeLenient), NamespaceModeStrict)
+
+	fallbacks := 0
+	for i := range urls {
+		entry := &urls[i]
+		fallback := false
+
+		if entry.News.XMLName.Local != "" && entry.News.XMLName.Space != googleNewsNamespace {
+			if strict && !knownNamespace(entry.News.XMLName.Space, googleNewsNamespace) {
+				entry.News = googleNewsDetail{}
+			} else {
+				fallback = true
+			}
+		}
+
+		images := entry.Images[:0]
+		for _, img := range entry.Images {
+			if img.XMLName.Space != googleImageNamespace {
+				if strict && !knownNamespace(img.XMLName.Space, googleImageNamespace) {
+					continue
+				}
+				fallback = true
+			}
+			images = append(images, img)
+		}
+		entry.Images = images
+
+		if fallback {
+			fallbacks++
+		}
+	}
+	return fallbacks
+}
+
+// knownNamespace reports whether space is canonical up to scheme (http/https), case and a trailing slash.
+func knownNamespace(space, canonical string) bool {
+	normalize := func(ns string) string {
+		ns = strings.ToLower(strings.TrimSpace(ns))
+		ns = strings.TrimPrefix(ns, "https://")
+		ns = strings.TrimPrefix(ns, "http://")
+		return strings.TrimSuffix(ns, "/")
+	}
+	return normalize(space) == normalize(canonical)
+}
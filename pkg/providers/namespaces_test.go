@@ -0,0 +1,73 @@
+package providers
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+const mixedNamespaceSitemap = `<?xml version="1.0"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9"
+        xmlns:news="http://www.google.com/schemas/sitemap-news/0.9"
+        xmlns:image="https://www.google.com/schemas/sitemap-image/1.1/">
+  <url>
+    <loc>https://example.com/canonical</loc>
+    <news:news><news:title>Canonical</news:title></news:news>
+  </url>
+  <url>
+    <loc>https://example.com/variant</loc>
+    <news:news><news:title>Variant image</news:title></news:news>
+    <image:image><image:loc>https://example.com/variant.jpg</image:loc></image:image>
+  </url>
+  <url>
+    <loc>https://example.com/undeclared</loc>
+    <gn:news><gn:title>Undeclared prefix</gn:title><gn:publication_date>2024-03-01T00:00:00Z</gn:publication_date></gn:news>
+  </url>
+</urlset>`
+
+func TestApplyNamespaceMode(t *testing.T) {
+	parse := func() []googleNewsURL {
+		contents, err := decodeSitemap[googleNewsURL](strings.NewReader(mixedNamespaceSitemap), 0)
+		if err != nil {
+			t.Fatalf("decodeSitemap: %v", err)
+		}
+		return contents.URLs
+	}
+
+	lenient := parse()
+	if n := applyNamespaceMode(Provider{}, lenient); n != 2 {
+		t.Fatalf("lenient fallbacks = %d, want 2", n)
+	}
+	if lenient[2].News.Title != "Undeclared prefix" || lenient[1].Images[0].Loc != "https://example.com/variant.jpg" {
+		t.Fatalf("lenient mode should keep every extension, got %#v", lenient)
+	}
+
+	strict := parse()
+	applyNamespaceMode(Provider{Config: map[string]any{ConfigNamespaceModeKey: "strict"}}, strict)
+	if strict[0].News.Title != "Canonical" {
+		t.Fatalf("strict mode must keep canonical news")
+	}
+	if len(strict[1].Images) != 1 {
+		t.Fatalf("strict mode must accept https/trailing-slash namespace variants")
+	}
+	if strict[2].News.Title != "" {
+		t.Fatalf("strict mode must drop unknown namespaces, got %#v", strict[2].News)
+	}
+}
+
+func TestGoogleNewsFetcherReportsNamespaceFallbacks(t *testing.T) {
+	client := &fakeHTTPClient{responses: map[string]fakeResponse{
+		"https://example.com/news.xml": {body: []byte(mixedNamespaceSitemap), statusCode: http.StatusOK},
+	}}
+	fetcher := NewGoogleNewsFetcher(client, nil)
+	cfg := Provider{ID: "p1", Type: ProviderTypeGoogleNews, SourceURL: "https://example.com/news.xml"}
+
+	if _, err := fetcher.Fetch(context.Background(), cfg); err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	stats := fetcher.(FetchStatsReporter).LastFetchStats("p1")
+	if stats[FetchStatNamespaceFallbacks] != 2 {
+		t.Fatalf("stats = %#v", stats)
+	}
+}
@@ -0,0 +1,194 @@
+package providers
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// opmlDocument models the parts of an OPML 2.0 subscription list used for import and export.
+type opmlDocument struct {
+	XMLName xml.Name    `xml:"opml"`
+	Version string      `xml:"version,attr"`
+	Head    opmlHead    `xml:"head"`
+	Body    opmlOutline `xml:"body"`
+}
+
+type opmlHead struct {
+	Title       string `xml:"title,omitempty"`
+	DateCreated string `xml:"dateCreated,omitempty"`
+}
+
+// opmlOutline is an OPML outline. ProviderType lives in the harvester's own namespace, since feed readers
+// expect the standard type attribute to be "rss".
+type opmlOutline struct {
+	Text         string        `xml:"text,attr,omitempty"`
+	Title        string        `xml:"title,attr,omitempty"`
+	Type         string        `xml:"type,attr,omitempty"`
+	ProviderType string        `xml:"https://github.com/samvad-hq/samvad-news-harvester type,attr,omitempty"`
+	XMLURL       string        `xml:"xmlUrl,attr,omitempty"`
+	HTMLURL      string        `xml:"htmlUrl,attr,omitempty"`
+	Category     string        `xml:"category,attr,omitempty"`
+	Outlines     []opmlOutline `xml:"outline"`
+}
+
+// ImportOPML converts the feeds of an OPML subscription list into provider entries. Enclosing folder
+// outlines and the category attribute become tags. Feeds keep the provider type ExportOPML recorded, and
+// are otherwise typed atom when the outline or URL says so and rss otherwise. userAgent, when set, is
+// written to each entry's config. Repeated feed URLs are kept once and ids are made unique.
+func ImportOPML(data []byte, userAgent string) ([]Provider, error) {
+	var doc opmlDocument
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("decode opml: %w", err)
+	}
+
+	var out []Provider
+	seen := make(map[string]struct{})
+	ids := make(map[string]int)
+	var walk func(outlines []opmlOutline, folders []string)
+	walk = func(outlines []opmlOutline, folders []string) {
+		for _, o := range outlines {
+			name := firstNonBlank(o.Title, o.Text)
+			feedURL := strings.TrimSpace(o.XMLURL)
+			if feedURL == "" {
+				walk(o.Outlines, append(folders[:len(folders):len(folders)], name))
+				continue
+			}
+			if _, dup := seen[feedURL]; dup || !isHTTPURL(feedURL) {
+				continue
+			}
+			seen[feedURL] = struct{}{}
+
+			p := Provider{
+				ID:             uniqueSlug(firstNonBlank(name, hostName(feedURL)), ids),
+				Name:           firstNonBlank(name, hostName(feedURL)),
+				Type:           opmlFeedType(o, feedURL),
+				Tags:           opmlTags(folders, o.Category),
+				SourceURL:      feedURL,
+				ResponseFormat: ResponseFormatAuto,
+			}
+			if userAgent != "" {
+				p.Config = map[string]any{ConfigUserAgentKey: userAgent}
+			}
+			out = append(out, p)
+		}
+	}
+	walk(doc.Body.Outlines, nil)
+
+	if len(out) == 0 {
+		return nil, fmt.Errorf("opml lists no feeds")
+	}
+	return out, nil
+}
+
+// ExportOPML renders feed providers as an OPML subscription list. Providers are grouped into folders by
+// their first tag, with any further tags in the category attribute. Only rss, atom and google_news_rss
+// providers with a single static source can be expressed as feeds; the ids of the rest are returned in
+// skipped. Outlines are typed rss, as feed readers expect, and carry the provider type in a namespaced attribute
+// so ImportOPML restores it.
+func ExportOPML(providers []Provider, title string, now time.Time) (data []byte, skipped []string, err error) {
+	var loose []opmlOutline
+	var folders []opmlOutline
+	folderIdx := make(map[string]int)
+	for _, p := range providers {
+		if !isFeedType(p.Type) || p.hasMultipleSources() || p.SourceURL == "" {
+			skipped = append(skipped, p.ID)
+			continue
+		}
+
+		outline := opmlOutline{
+			Text:         p.Name,
+			Title:        p.Name,
+			Type:         "rss",
+			ProviderType: strings.ToLower(p.Type),
+			XMLURL:       p.SourceURL,
+			HTMLURL:      siteURL(p.SourceURL),
+		}
+		if len(p.Tags) == 0 {
+			loose = append(loose, outline)
+			continue
+		}
+		outline.Category = strings.Join(p.Tags[1:], ",")
+		i, ok := folderIdx[p.Tags[0]]
+		if !ok {
+			i = len(folders)
+			folderIdx[p.Tags[0]] = i
+			folders = append(folders, opmlOutline{Text: p.Tags[0], Title: p.Tags[0]})
+		}
+		folders[i].Outlines = append(folders[i].Outlines, outline)
+	}
+
+	doc := opmlDocument{
+		Version: "2.0",
+		Head:    opmlHead{Title: title, DateCreated: now.UTC().Format(time.RFC1123Z)},
+		Body:    opmlOutline{Outlines: append(folders, loose...)},
+	}
+	out, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, skipped, fmt.Errorf("encode opml: %w", err)
+	}
+	return append([]byte(xml.Header), append(out, '\n')...), skipped, nil
+}
+
+// isFeedType reports whether providers of type typ read an RSS or Atom feed.
+func isFeedType(typ string) bool {
+	switch strings.ToLower(typ) {
+	case ProviderTypeRSS, ProviderTypeAtom, ProviderTypeGoogleNewsRSS:
+		return true
+	default:
+		return false
+	}
+}
+
+// opmlFeedType returns the provider type recorded on the outline by ExportOPML, or picks atom when the
+// outline type or feed URL mentions it, rss otherwise.
+func opmlFeedType(o opmlOutline, feedURL string) string {
+	if typ := strings.ToLower(strings.TrimSpace(o.ProviderType)); isFeedType(typ) {
+		return typ
+	}
+	if strings.EqualFold(strings.TrimSpace(o.Type), ProviderTypeAtom) {
+		return ProviderTypeAtom
+	}
+	if u, err := url.Parse(feedURL); err == nil && strings.Contains(strings.ToLower(u.Path+"?"+u.RawQuery), "atom") {
+		return ProviderTypeAtom
+	}
+	return ProviderTypeRSS
+}
+
+// opmlTags combines folder names with the comma-separated, slash-delimited category paths, without
+// duplicates.
+func opmlTags(folders []string, category string) []string {
+	var tags []string
+	seen := make(map[string]struct{})
+	add := func(tag string) {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			return
+		}
+		if _, dup := seen[strings.ToLower(tag)]; dup {
+			return
+		}
+		seen[strings.ToLower(tag)] = struct{}{}
+		tags = append(tags, tag)
+	}
+	for _, folder := range folders {
+		add(folder)
+	}
+	for _, path := range strings.Split(category, ",") {
+		for _, segment := range strings.Split(path, "/") {
+			add(segment)
+		}
+	}
+	return tags
+}
+
+// siteURL reduces a feed URL to its scheme://host homepage.
+func siteURL(feedURL string) string {
+	root, err := discoveryRoot(feedURL)
+	if err != nil {
+		return ""
+	}
+	return root
+}
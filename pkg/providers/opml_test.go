@@ -0,0 +1,113 @@
+package providers
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+const sampleOPML = `<?xml version="1.0" encoding="UTF-8"?>
+<opml version="2.0">
+  <head><title>Desk feeds</title></head>
+  <body>
+    <outline text="India">
+      <outline text="Politics">
+        <outline type="rss" text="Example Politics" xmlUrl="https://example.com/politics/feed.xml" category="/elections,States/Kerala"/>
+      </outline>
+      <outline type="rss" text="Example Blog" title="Example Blog" xmlUrl="https://blog.example.org/atom.xml"/>
+    </outline>
+    <outline type="rss" text="Example Politics" xmlUrl="https://other.example/politics.rss"/>
+    <outline type="rss" text="Duplicate" xmlUrl="https://example.com/politics/feed.xml"/>
+    <outline type="atom" text="Loose" xmlUrl="https://loose.example/feed"/>
+    <outline type="rss" text="Not http" xmlUrl="ftp://example.com/feed"/>
+  </body>
+</opml>`
+
+func TestImportOPML(t *testing.T) {
+	imported, err := ImportOPML([]byte(sampleOPML), "ua")
+	if err != nil {
+		t.Fatalf("ImportOPML: %v", err)
+	}
+	if len(imported) != 4 {
+		t.Fatalf("expected 4 providers, got %d: %+v", len(imported), imported)
+	}
+
+	first := imported[0]
+	if first.ID != "example-politics" || first.Type != ProviderTypeRSS || first.ResponseFormat != ResponseFormatAuto {
+		t.Fatalf("unexpected first provider: %+v", first)
+	}
+	if want := []string{"India", "Politics", "elections", "States", "Kerala"}; !slices.Equal(first.Tags, want) {
+		t.Fatalf("expected tags %v, got %v", want, first.Tags)
+	}
+	if ConfigString(first, ConfigUserAgentKey, "") != "ua" {
+		t.Fatalf("expected user agent in config, got %v", first.Config)
+	}
+	if imported[1].Type != ProviderTypeAtom || !slices.Equal(imported[1].Tags, []string{"India"}) {
+		t.Fatalf("expected atom feed tagged India, got %+v", imported[1])
+	}
+	if imported[2].ID != "example-politics-2" || len(imported[2].Tags) != 0 {
+		t.Fatalf("expected unique id and no tags, got %+v", imported[2])
+	}
+	if imported[3].Type != ProviderTypeAtom {
+		t.Fatalf("expected outline type atom to be honoured, got %q", imported[3].Type)
+	}
+
+	for _, p := range imported {
+		if err := validateProvider(sanitizeProvider(p)); err != nil {
+			t.Fatalf("imported provider %q is invalid: %v", p.ID, err)
+		}
+	}
+}
+
+func TestImportOPMLWithoutFeeds(t *testing.T) {
+	if _, err := ImportOPML([]byte(`<opml version="2.0"><body><outline text="Empty"/></body></opml>`), ""); err == nil {
+		t.Fatalf("expected error for opml without feeds")
+	}
+	if _, err := ImportOPML([]byte(`not xml`), ""); err == nil {
+		t.Fatalf("expected error for invalid opml")
+	}
+}
+
+func TestExportOPMLRoundTrip(t *testing.T) {
+	registry := []Provider{
+		{ID: "politics", Name: "Example Politics", Type: ProviderTypeRSS, Tags: []string{"India", "elections"}, SourceURL: "https://example.com/politics/feed.xml"},
+		{ID: "blog", Name: "Example Blog", Type: ProviderTypeAtom, Tags: []string{"India"}, SourceURL: "https://blog.example.org/feed"},
+		{ID: "wire", Name: "Wire", Type: ProviderTypeRSS, SourceURL: "https://wire.example/rss"},
+		{ID: "isro", Name: "ISRO on Google News", Type: ProviderTypeGoogleNewsRSS, SourceURL: "https://news.google.com/rss/search?q=ISRO"},
+		{ID: "topic", Name: "World", Type: ProviderTypeGoogleNewsRSS, Config: map[string]any{ConfigTopicKey: "WORLD"}},
+		{ID: "sitemap", Name: "Sitemap", Type: ProviderTypeGoogleNews, SourceURL: "https://example.com/news.xml"},
+		{ID: "daily", Name: "Daily", Type: ProviderTypeRSS, SourceURL: "https://example.com/{date}/rss"},
+	}
+
+	data, skipped, err := ExportOPML(registry, "Desk feeds", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("ExportOPML: %v", err)
+	}
+	if !slices.Equal(skipped, []string{"topic", "sitemap", "daily"}) {
+		t.Fatalf("unexpected skipped providers: %v", skipped)
+	}
+	out := string(data)
+	for _, want := range []string{`<title>Desk feeds</title>`, `<outline text="India" title="India">`, `htmlUrl="https://example.com"`, `:type="google_news_rss"`} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in output:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, ` type="atom"`) || strings.Contains(out, ` type="google_news_rss"`) {
+		t.Fatalf("expected every outline to be typed rss:\n%s", out)
+	}
+
+	imported, err := ImportOPML(data, "")
+	if err != nil {
+		t.Fatalf("ImportOPML: %v", err)
+	}
+	if len(imported) != 4 {
+		t.Fatalf("expected 4 providers after round trip, got %+v", imported)
+	}
+	for i, want := range registry[:4] {
+		got := imported[i]
+		if got.Name != want.Name || got.Type != want.Type || got.SourceURL != want.SourceURL || !slices.Equal(got.Tags, want.Tags) {
+			t.Fatalf("round trip %d: got %+v, want %+v", i, got, want)
+		}
+	}
+}
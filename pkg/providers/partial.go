@@ -0,0 +1,55 @@
+package providers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// SourceFailure records a provider source (e.g. a nested sitemap) that could not be fetched or parsed.
+type SourceFailure struct {
+	URL string
+	Err error
+}
+
+// PartialError is returned by Fetch together with the articles that were collected when only some of a
+// provider's sources failed. Callers should treat the run as degraded rather than failed.
+type PartialError struct {
+	Failures []SourceFailure
+}
+
+// Error summarises the failed sources.
+func (e *PartialError) Error() string {
+	parts := make([]string, 0, len(e.Failures))
+	for _, f := range e.Failures {
+		parts = append(parts, fmt.Sprintf("%s: %v", f.URL, f.Err))
+	}
+	return fmt.Sprintf("%d source(s) failed: %s", len(e.Failures), strings.Join(parts, "; "))
+}
+
+// Unwrap exposes the individual source errors to errors.Is/As.
+func (e *PartialError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failures))
+	for _, f := range e.Failures {
+		errs = append(errs, f.Err)
+	}
+	return errs
+}
+
+// URLs returns the failed source URLs.
+func (e *PartialError) URLs() []string {
+	urls := make([]string, 0, len(e.Failures))
+	for _, f := range e.Failures {
+		urls = append(urls, f.URL)
+	}
+	return urls
+}
+
+// IsPartial reports whether err is (or wraps) a PartialError, returning it when so.
+func IsPartial(err error) (*PartialError, bool) {
+	var partial *PartialError
+	if errors.As(err, &partial) {
+		return partial, true
+	}
+	return nil, false
+}
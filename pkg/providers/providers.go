@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
@@ -16,13 +17,19 @@ import (
 
 // Provider represents the configuration for a news provider.
 type Provider struct {
-	ID             string         `json:"id" yaml:"id"`
-	Name           string         `json:"name" yaml:"name"`
-	Type           string         `json:"type" yaml:"type"`
-	SourceURL      string         `json:"source_url" yaml:"source_url"`
-	ResponseFormat string         `json:"response_format" yaml:"response_format"`
-	RequestDelayMs int            `json:"request_delay_ms" yaml:"request_delay_ms"`
-	Config         map[string]any `json:"config" yaml:"config"`
+	ID             string            `json:"id" yaml:"id"`
+	Name           string            `json:"name" yaml:"name"`
+	Type           string            `json:"type" yaml:"type"`
+	Tags           []string          `json:"tags" yaml:"tags,omitempty"`
+	SourceURL      string            `json:"source_url" yaml:"source_url"`
+	SourceURLs     []string          `json:"source_urls" yaml:"source_urls,omitempty"`
+	ResponseFormat string            `json:"response_format" yaml:"response_format"`
+	RequestDelayMs int               `json:"request_delay_ms" yaml:"request_delay_ms,omitempty"`
+	MaxAge         string            `json:"max_age" yaml:"max_age,omitempty"`
+	MaxArticles    int               `json:"max_articles" yaml:"max_articles,omitempty"`
+	Headers        map[string]string `json:"headers" yaml:"headers,omitempty"`
+	Auth           *Auth             `json:"auth" yaml:"auth,omitempty"`
+	Config         map[string]any    `json:"config" yaml:"config,omitempty"`
 }
 
 // registryFile models the structure of the providers file.
@@ -159,7 +166,23 @@ func sanitizeProvider(p Provider) Provider {
 	p.Name = strings.TrimSpace(p.Name)
 	p.Type = strings.ToLower(strings.TrimSpace(p.Type))
 	p.SourceURL = strings.TrimSpace(p.SourceURL)
-	p.ResponseFormat = strings.TrimSpace(p.ResponseFormat)
+	p.SourceURLs = trimmedValues(p.SourceURLs)
+	p.Tags = trimmedValues(p.Tags)
+	if p.Type == ProviderTypeGoogleNewsRSS && p.SourceURL == "" && len(p.SourceURLs) == 0 {
+		p.SourceURL = GoogleNewsRSSURL(p)
+	}
+	p.ResponseFormat = strings.ToLower(strings.TrimSpace(p.ResponseFormat))
+	p.MaxAge = strings.TrimSpace(p.MaxAge)
+	p.Auth = sanitizeAuth(p.Auth)
+	if len(p.Headers) > 0 {
+		headers := make(map[string]string, len(p.Headers))
+		for k, v := range p.Headers {
+			if k = strings.TrimSpace(k); k != "" {
+				headers[http.CanonicalHeaderKey(k)] = strings.TrimSpace(expandEnv(v))
+			}
+		}
+		p.Headers = headers
+	}
 
 	if p.Config == nil {
 		p.Config = map[string]any{}
@@ -182,12 +205,37 @@ func validateProvider(p Provider) error {
 	if p.Type == "" {
 		return fmt.Errorf("type is required for provider %q", p.ID)
 	}
-	if p.SourceURL == "" {
-		return fmt.Errorf("source_url is required for provider %q", p.ID)
+	if p.SourceURL == "" && len(p.SourceURLs) == 0 && p.Type == ProviderTypeGoogleNewsRSS {
+		return fmt.Errorf("source_url or config %s/%s is required for provider %q", ConfigQueryKey, ConfigTopicKey, p.ID)
+	}
+	if p.SourceURL == "" && len(p.SourceURLs) == 0 {
+		return fmt.Errorf("source_url or source_urls is required for provider %q", p.ID)
+	}
+	if tz := ConfigString(p, ConfigTimezoneKey, ""); tz != "" {
+		if _, err := time.LoadLocation(tz); err != nil {
+			return fmt.Errorf("config %s %q is not a known IANA timezone for provider %q", ConfigTimezoneKey, tz, p.ID)
+		}
+	}
+	if _, err := p.Sources(time.Now()); err != nil {
+		return fmt.Errorf("provider %q: %w", p.ID, err)
 	}
 	if p.ResponseFormat == "" {
 		return fmt.Errorf("response_format is required for provider %q", p.ID)
 	}
+	if !validResponseFormat(p.ResponseFormat) {
+		return fmt.Errorf("response_format %q is not supported for provider %q (expected xml, xml.gz, json, html or auto)", p.ResponseFormat, p.ID)
+	}
+	if p.MaxAge != "" {
+		if d, err := time.ParseDuration(p.MaxAge); err != nil || d <= 0 {
+			return fmt.Errorf("max_age %q is not a positive duration for provider %q", p.MaxAge, p.ID)
+		}
+	}
+	if err := p.Auth.validate(); err != nil {
+		return fmt.Errorf("provider %q: %w", p.ID, err)
+	}
+	if p.MaxArticles < 0 {
+		return fmt.Errorf("max_articles must not be negative for provider %q", p.ID)
+	}
 	return nil
 }
 
@@ -198,3 +246,15 @@ func (p Provider) RequestDelay() time.Duration {
 	}
 	return time.Duration(p.RequestDelayMs) * time.Millisecond
 }
+
+// MaxAgeDuration returns the provider's freshness window, or 0 when max_age is unset or invalid.
+func (p Provider) MaxAgeDuration() time.Duration {
+	if p.MaxAge == "" {
+		return 0
+	}
+	d, err := time.ParseDuration(p.MaxAge)
+	if err != nil || d <= 0 {
+		return 0
+	}
+	return d
+}
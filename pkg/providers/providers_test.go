@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -67,6 +68,56 @@ providers:
 	}
 }
 
+func TestLoadRegistryFreshnessLimits(t *testing.T) {
+	dir := t.TempDir()
+	path := writeTempFile(t, dir, "providers.yaml", `
+providers:
+  - id: foo
+    name: Foo
+    type: google_news_sitemap
+    source_url: https://example.com
+    response_format: xml
+    max_age: " 36h "
+    max_articles: 20
+`)
+
+	reg, err := LoadRegistry(path)
+	if err != nil {
+		t.Fatalf("LoadRegistry: %v", err)
+	}
+	got, _ := reg.ByID("foo")
+	if got.MaxAgeDuration() != 36*time.Hour || got.MaxArticles != 20 {
+		t.Fatalf("unexpected limits max_age=%v max_articles=%d", got.MaxAgeDuration(), got.MaxArticles)
+	}
+
+	bad := writeTempFile(t, dir, "bad.yaml", `
+providers:
+  - id: foo
+    name: Foo
+    type: google_news_sitemap
+    source_url: https://example.com
+    response_format: xml
+    max_age: two days
+`)
+	if _, err := LoadRegistry(bad); err == nil {
+		t.Fatalf("expected error for invalid max_age")
+	}
+
+	badZone := writeTempFile(t, dir, "bad_zone.yaml", `
+providers:
+  - id: foo
+    name: Foo
+    type: google_news_sitemap
+    source_url: https://example.com
+    response_format: xml
+    config:
+      timezone: Asia/Bombay_Typo
+`)
+	if _, err := LoadRegistry(badZone); err == nil || !strings.Contains(err.Error(), "timezone") {
+		t.Fatalf("expected error for unknown timezone, got %v", err)
+	}
+}
+
 func TestFetcherRegistryResolution(t *testing.T) {
 	reg := NewTypeFetcherRegistry(map[string]Fetcher{
 		"custom": &stubFetcher{id: "custom"},
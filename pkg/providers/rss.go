@@ -0,0 +1,63 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/samvad-hq/samvad-news-harvester/internal/domain"
+)
+
+// rssFetcher implements Fetcher for RSS 2.0 feed providers.
+type rssFetcher struct {
+	client HTTPClient
+	validatorCache
+	fetchStats
+}
+
+// NewRSSFetcher builds a Fetcher for RSS 2.0 feed providers.
+// validators is optional; when set, unchanged sources are skipped via conditional GETs.
+func NewRSSFetcher(client HTTPClient, validators ValidatorStore) Fetcher {
+	if client == nil {
+		client = DefaultHTTPClient()
+	}
+	return &rssFetcher{client: client, validatorCache: validatorCache{store: validators}}
+}
+
+// ID returns the provider type for the RSS fetcher.
+func (f *rssFetcher) ID() string {
+	return ProviderTypeRSS
+}
+
+// Fetch retrieves articles from an RSS 2.0 feed provider.
+func (f *rssFetcher) Fetch(ctx context.Context, cfg Provider) ([]domain.Article, error) {
+	if !strings.EqualFold(cfg.Type, ProviderTypeRSS) {
+		return nil, fmt.Errorf("rss fetcher received incompatible provider type %q", cfg.Type)
+	}
+	if strings.TrimSpace(cfg.SourceURL) == "" {
+		return nil, fmt.Errorf("provider %q source_url is empty", cfg.ID)
+	}
+
+	doc, err := fetchDocument(ctx, f.client, cfg.SourceURL, cfg, "feed", ResponseFormatXML, conditionalHeaders(f.store, cfg.SourceURL, Headers(cfg)))
+	if err != nil {
+		return nil, err
+	}
+	if doc.NotModified {
+		f.holdSource(cfg.ID, cfg.SourceURL, doc.Validators)
+		return nil, nil
+	}
+
+	items, err := parseRSSFeed(doc.Body)
+	if err != nil {
+		return nil, fmt.Errorf("decode rss feed: %w", err)
+	}
+
+	dates := newDateParser(cfg)
+	articles := buildArticlesFromRSS(cfg.ID, items, dates)
+	f.record(cfg.ID, map[string]int{FetchStatDateParseFailures: dates.failures})
+	if len(articles) == 0 {
+		return nil, fmt.Errorf("%s feed returned no records", cfg.ID)
+	}
+	f.holdSource(cfg.ID, cfg.SourceURL, doc.Validators)
+	return articles, nil
+}
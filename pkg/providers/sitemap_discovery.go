@@ -0,0 +1,353 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/samvad-hq/samvad-news-harvester/internal/domain"
+	"github.com/samvad-hq/samvad-news-harvester/pkg/robots"
+)
+
+const (
+	// ConfigDiscoveryTTLKey controls how long discovered sitemap URLs are reused before rediscovery.
+	ConfigDiscoveryTTLKey = "discovery_ttl"
+	// ConfigDiscoveryChildMatchKey lists comma-separated words, one of which a sitemap URL's path must contain
+	// for discovery to treat it as a news sitemap and keep it from an index; "*" keeps every child. Children
+	// must carry news entries either way.
+	ConfigDiscoveryChildMatchKey = "discovery_child_match"
+
+	defaultDiscoveryChildMatch = "news"
+
+	defaultDiscoveryTTL = 24 * time.Hour
+
+	// maxDiscoveryProbes bounds the number of candidate sitemaps fetched while discovering a site.
+	maxDiscoveryProbes = 25
+)
+
+// wellKnownSitemapPaths are probed in addition to the Sitemap: lines from robots.txt.
+var wellKnownSitemapPaths = []string{
+	"/news-sitemap.xml",
+	"/sitemap-news.xml",
+	"/news_sitemap.xml",
+	"/sitemap_news.xml",
+	"/sitemap_index.xml",
+	"/sitemap.xml",
+}
+
+// sitemapDiscoveryFetcher implements Fetcher for providers configured with only a site root.
+// It locates the site's Google News sitemaps and parses them like google_news_sitemap providers.
+type sitemapDiscoveryFetcher struct {
+	client    HTTPClient
+	discovery DiscoveryStore
+	now       func() time.Time
+
+	mu         sync.Mutex
+	discovered map[string]domain.DiscoveredSitemaps
+	validatorCache
+	fetchStats
+}
+
+// NewSitemapDiscoveryFetcher builds a Fetcher for sitemap_discovery providers.
+// validators is optional; when set, unchanged leaf sitemaps are skipped via conditional GETs, and when it
+// is also a DiscoveryStore, discovered sitemaps are persisted there.
+func NewSitemapDiscoveryFetcher(client HTTPClient, validators ValidatorStore) Fetcher {
+	if client == nil {
+		client = DefaultHTTPClient()
+	}
+	discovery, _ := validators.(DiscoveryStore)
+	return &sitemapDiscoveryFetcher{
+		client:         client,
+		discovery:      discovery,
+		validatorCache: validatorCache{store: validators},
+		now:            time.Now,
+		discovered:     make(map[string]domain.DiscoveredSitemaps),
+	}
+}
+
+// ID returns the provider type for the sitemap discovery fetcher.
+func (f *sitemapDiscoveryFetcher) ID() string {
+	return ProviderTypeSitemapDiscovery
+}
+
+// Fetch discovers (or reuses) the site's news sitemaps and retrieves their articles.
+func (f *sitemapDiscoveryFetcher) Fetch(ctx context.Context, cfg Provider) ([]domain.Article, error) {
+	if !strings.EqualFold(cfg.Type, ProviderTypeSitemapDiscovery) {
+		return nil, fmt.Errorf("sitemap discovery fetcher received incompatible provider type %q", cfg.Type)
+	}
+	if strings.TrimSpace(cfg.SourceURL) == "" {
+		return nil, fmt.Errorf("provider %q source_url is empty", cfg.ID)
+	}
+
+	headers := Headers(cfg)
+
+	sitemaps, err := f.sitemaps(ctx, cfg, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	walker := newSitemapWalker[googleNewsURL](f.client, f.store, cfg, headers)
+	var urls []googleNewsURL
+	for _, sitemapURL := range sitemaps {
+		entries, err := walker.walk(ctx, sitemapURL)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+			walker.fail(SourceFailure{URL: sitemapURL, Err: err})
+			continue
+		}
+		urls = append(urls, entries...)
+	}
+	f.hold(cfg.ID, walker.validators())
+
+	// Any failure may mean the site moved its sitemaps; rediscover on the next run.
+	err = walker.failureErr()
+	if err != nil {
+		f.forget(cfg.ID)
+	}
+	if _, partial := IsPartial(err); err != nil && !partial {
+		return nil, err
+	}
+	if len(urls) == 0 && walker.unchanged > 0 {
+		return nil, err
+	}
+
+	fallbacks := applyNamespaceMode(cfg, urls)
+	dates := newDateParser(cfg)
+	articles := buildArticlesFromSitemap(cfg.ID, urls, dates)
+	stats := walker.limitStats()
+	stats[FetchStatNamespaceFallbacks] = fallbacks
+	stats[FetchStatDateParseFailures] = dates.failures
+	f.record(cfg.ID, stats)
+	if len(articles) == 0 {
+		return nil, fmt.Errorf("%s discovered sitemaps returned no records", cfg.ID)
+	}
+	return articles, err
+}
+
+// sitemaps returns the cached news sitemaps for the provider, discovering them when missing or expired.
+// Results are kept in memory and, when a DiscoveryStore is set, persisted across restarts.
+func (f *sitemapDiscoveryFetcher) sitemaps(ctx context.Context, cfg Provider, headers map[string]string) ([]string, error) {
+	root, err := discoveryRoot(cfg.SourceURL)
+	if err != nil {
+		return nil, fmt.Errorf("provider %q source_url: %w", cfg.ID, err)
+	}
+
+	now := f.now()
+	if cached, ok := f.cached(cfg.ID); ok && cached.Root == root && now.Before(cached.Expires) {
+		return cached.Sitemaps, nil
+	}
+
+	sitemaps := f.discover(ctx, cfg, root, headers)
+	if len(sitemaps) == 0 {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%s no news sitemaps discovered at %s", cfg.ID, root)
+	}
+
+	result := domain.DiscoveredSitemaps{
+		Root:     root,
+		Sitemaps: sitemaps,
+		Expires:  now.Add(ConfigDuration(cfg, ConfigDiscoveryTTLKey, defaultDiscoveryTTL)),
+	}
+	f.mu.Lock()
+	f.discovered[cfg.ID] = result
+	f.mu.Unlock()
+	if f.discovery != nil {
+		// Losing the persisted copy only costs a rediscovery after a restart.
+		_ = f.discovery.SaveDiscoveredSitemaps(cfg.ID, result)
+	}
+	return sitemaps, nil
+}
+
+// cached returns the discovery result held in memory for a provider, falling back to the DiscoveryStore.
+func (f *sitemapDiscoveryFetcher) cached(providerID string) (domain.DiscoveredSitemaps, bool) {
+	f.mu.Lock()
+	cached, ok := f.discovered[providerID]
+	f.mu.Unlock()
+	if ok || f.discovery == nil {
+		return cached, ok
+	}
+
+	stored, err := f.discovery.DiscoveredSitemaps(providerID)
+	if err != nil || len(stored.Sitemaps) == 0 {
+		return domain.DiscoveredSitemaps{}, false
+	}
+	f.mu.Lock()
+	f.discovered[providerID] = stored
+	f.mu.Unlock()
+	return stored, true
+}
+
+// forget drops the cached discovery result for a provider.
+func (f *sitemapDiscoveryFetcher) forget(providerID string) {
+	f.mu.Lock()
+	delete(f.discovered, providerID)
+	f.mu.Unlock()
+	if f.discovery != nil {
+		_ = f.discovery.SaveDiscoveredSitemaps(providerID, domain.DiscoveredSitemaps{})
+	}
+}
+
+// discover collects candidate sitemaps from robots.txt and well-known paths and keeps the news sitemaps.
+func (f *sitemapDiscoveryFetcher) discover(ctx context.Context, cfg Provider, root string, headers map[string]string) []string {
+	match := childMatch(cfg)
+	probe := &sitemapProbe{fetcher: f, cfg: cfg, headers: headers, match: match, budget: maxDiscoveryProbes}
+
+	var found []string
+	seen := make(map[string]struct{})
+	for _, candidate := range f.candidates(ctx, root, headers, match) {
+		if ctx.Err() != nil {
+			break
+		}
+		for _, sitemapURL := range probe.classify(ctx, candidate, true) {
+			if _, dup := seen[sitemapURL]; dup {
+				continue
+			}
+			seen[sitemapURL] = struct{}{}
+			found = append(found, sitemapURL)
+		}
+	}
+	return found
+}
+
+// candidates lists robots.txt Sitemap: URLs followed by the well-known paths, without duplicates.
+// News-looking URLs are tried first so the probe budget is spent where it matters.
+func (f *sitemapDiscoveryFetcher) candidates(ctx context.Context, root string, headers map[string]string, match []string) []string {
+	var all []string
+	if resp, err := f.client.Get(ctx, root+"/robots.txt", headers); err == nil && resp.StatusCode() == http.StatusOK {
+		all = append(all, robots.Parse(resp.Body()).Sitemaps()...)
+	}
+	for _, path := range wellKnownSitemapPaths {
+		all = append(all, root+path)
+	}
+
+	seen := make(map[string]struct{}, len(all))
+	var news, other []string
+	for _, candidate := range all {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "" {
+			continue
+		}
+		if _, dup := seen[candidate]; dup {
+			continue
+		}
+		seen[candidate] = struct{}{}
+		if looksLikeNewsSitemap(candidate, match) {
+			news = append(news, candidate)
+		} else {
+			other = append(other, candidate)
+		}
+	}
+	return append(news, other...)
+}
+
+// sitemapProbe fetches candidate sitemaps within a fixed request budget.
+type sitemapProbe struct {
+	fetcher *sitemapDiscoveryFetcher
+	cfg     Provider
+	headers map[string]string
+	match   []string
+	budget  int
+}
+
+// classify returns the news sitemaps reachable from sitemapURL. A news sitemap is returned as is; an index
+// is returned whole when its own URL contains a match word, otherwise only its children that look like
+// news sitemaps and carry news entries are kept. Children are inspected one level deep only.
+func (p *sitemapProbe) classify(ctx context.Context, sitemapURL string, followIndex bool) []string {
+	if p.budget <= 0 {
+		return nil
+	}
+	p.budget--
+
+	doc, err := streamSitemapDocument[googleNewsURL](ctx, p.fetcher.client, sitemapURL, p.cfg, p.headers, defaultSitemapMaxEntries)
+	if err != nil || doc.NotModified {
+		return nil
+	}
+	if doc.Kind == sitemapKindURLSet {
+		if hasNewsEntries(doc.URLs) {
+			return []string{sitemapURL}
+		}
+		return nil
+	}
+	if !followIndex {
+		return nil
+	}
+
+	children := doc.Sitemaps
+	var news []string
+	for _, child := range children {
+		if !looksLikeNewsSitemap(child.Loc, p.match) {
+			continue
+		}
+		if len(p.classify(ctx, child.Loc, false)) == 0 {
+			continue
+		}
+		if len(p.match) > 0 && looksLikeNewsSitemap(sitemapURL, p.match) {
+			return []string{sitemapURL}
+		}
+		news = append(news, child.Loc)
+	}
+	return news
+}
+
+// hasNewsEntries reports whether any entry carries <news:news> metadata.
+func hasNewsEntries(entries []googleNewsURL) bool {
+	for _, entry := range entries {
+		if strings.TrimSpace(entry.News.Title) != "" || strings.TrimSpace(entry.News.PublicationDate) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// childMatch returns the lowercase words of the provider's discovery_child_match setting; nil matches every
+// sitemap.
+func childMatch(cfg Provider) []string {
+	raw := ConfigString(cfg, ConfigDiscoveryChildMatchKey, defaultDiscoveryChildMatch)
+	if raw == "*" {
+		return nil
+	}
+	return trimmedValues(strings.Split(strings.ToLower(raw), ","))
+}
+
+// looksLikeNewsSitemap reports whether a sitemap URL's path contains one of the match words (any URL when
+// match is empty).
+func looksLikeNewsSitemap(raw string, match []string) bool {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+	if len(match) == 0 {
+		return true
+	}
+	path := strings.ToLower(u.Path)
+	for _, word := range match {
+		if strings.Contains(path, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// discoveryRoot reduces a configured site URL to scheme://host.
+func discoveryRoot(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", err
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("%q has no host", raw)
+	}
+	return u.Scheme + "://" + u.Host, nil
+}
@@ -0,0 +1,174 @@
+package providers
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/samvad-hq/samvad-news-harvester/internal/domain"
+)
+
+const discoveryNewsLeaf = `<?xml version="1.0"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9" xmlns:news="http://www.google.com/schemas/sitemap-news/0.9">
+  <url>
+    <loc>https://example.com/story</loc>
+    <news:news>
+      <news:title>Story</news:title>
+      <news:publication_date>2024-05-01T10:00:00Z</news:publication_date>
+    </news:news>
+  </url>
+</urlset>`
+
+func TestSitemapDiscoveryFetcherFindsNewsSitemaps(t *testing.T) {
+	client := &fakeHTTPClient{
+		responses: map[string]fakeResponse{
+			"https://example.com/robots.txt": {
+				body:       []byte("User-agent: *\nDisallow:\nSitemap: https://example.com/sitemaps/index.xml\n"),
+				statusCode: http.StatusOK,
+			},
+			"https://example.com/sitemaps/index.xml": {
+				body: []byte(`<sitemapindex>
+  <sitemap><loc>https://example.com/sitemaps/pages.xml</loc></sitemap>
+  <sitemap><loc>https://example.com/sitemaps/news-latest.xml</loc></sitemap>
+</sitemapindex>`),
+				statusCode: http.StatusOK,
+			},
+			"https://example.com/sitemaps/news-latest.xml": {body: []byte(discoveryNewsLeaf), statusCode: http.StatusOK},
+		},
+	}
+
+	fetcher := NewSitemapDiscoveryFetcher(client, nil)
+	cfg := Provider{ID: "disc", Type: ProviderTypeSitemapDiscovery, SourceURL: "example.com"}
+
+	articles, err := fetcher.Fetch(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if len(articles) != 1 || articles[0].URL != "https://example.com/story" || articles[0].Title != "Story" {
+		t.Fatalf("unexpected articles %#v", articles)
+	}
+
+	probes := len(client.calls)
+	if _, err := fetcher.Fetch(context.Background(), cfg); err != nil {
+		t.Fatalf("second Fetch: %v", err)
+	}
+	if extra := client.calls[probes:]; len(extra) != 1 || extra[0] != "https://example.com/sitemaps/news-latest.xml" {
+		t.Fatalf("expected cached discovery to fetch only the news sitemap, got %v", extra)
+	}
+
+	f := fetcher.(*sitemapDiscoveryFetcher)
+	f.now = func() time.Time { return time.Now().Add(2 * defaultDiscoveryTTL) }
+	probes = len(client.calls)
+	if _, err := fetcher.Fetch(context.Background(), cfg); err != nil {
+		t.Fatalf("third Fetch: %v", err)
+	}
+	if client.calls[probes] != "https://example.com/robots.txt" {
+		t.Fatalf("expected rediscovery after ttl, got %v", client.calls[probes:])
+	}
+}
+
+func TestSitemapDiscoveryFetcherWellKnownPaths(t *testing.T) {
+	client := &fakeHTTPClient{
+		responses: map[string]fakeResponse{
+			"https://example.com/news-sitemap.xml": {body: []byte(discoveryNewsLeaf), statusCode: http.StatusOK},
+			"https://example.com/sitemap.xml": {
+				body:       []byte(`<urlset><url><loc>https://example.com/about</loc></url></urlset>`),
+				statusCode: http.StatusOK,
+			},
+		},
+	}
+
+	f := NewSitemapDiscoveryFetcher(client, nil).(*sitemapDiscoveryFetcher)
+	cfg := Provider{ID: "disc", Type: ProviderTypeSitemapDiscovery, SourceURL: "https://example.com/section/"}
+	sitemaps, err := f.sitemaps(context.Background(), cfg, nil)
+	if err != nil {
+		t.Fatalf("sitemaps: %v", err)
+	}
+	if len(sitemaps) != 1 || sitemaps[0] != "https://example.com/news-sitemap.xml" {
+		t.Fatalf("unexpected discovered sitemaps %v", sitemaps)
+	}
+}
+
+func TestSitemapDiscoveryFetcherNoSitemaps(t *testing.T) {
+	fetcher := NewSitemapDiscoveryFetcher(&fakeHTTPClient{}, nil)
+	_, err := fetcher.Fetch(context.Background(), Provider{ID: "disc", Type: ProviderTypeSitemapDiscovery, SourceURL: "https://example.com"})
+	if err == nil {
+		t.Fatalf("expected error when nothing is discovered")
+	}
+}
+
+// memoryDiscoveryStore is a ValidatorStore that also persists discovery results.
+type memoryDiscoveryStore struct {
+	memoryValidatorStore
+	discovered map[string]domain.DiscoveredSitemaps
+}
+
+func (m *memoryDiscoveryStore) DiscoveredSitemaps(providerID string) (domain.DiscoveredSitemaps, error) {
+	return m.discovered[providerID], nil
+}
+
+func (m *memoryDiscoveryStore) SaveDiscoveredSitemaps(providerID string, d domain.DiscoveredSitemaps) error {
+	m.discovered[providerID] = d
+	return nil
+}
+
+func TestSitemapDiscoveryFetcherPersistsDiscovery(t *testing.T) {
+	client := &fakeHTTPClient{
+		responses: map[string]fakeResponse{
+			"https://example.com/news-sitemap.xml": {body: []byte(discoveryNewsLeaf), statusCode: http.StatusOK},
+		},
+	}
+	store := &memoryDiscoveryStore{memoryValidatorStore: memoryValidatorStore{}, discovered: map[string]domain.DiscoveredSitemaps{}}
+	cfg := Provider{ID: "disc", Type: ProviderTypeSitemapDiscovery, SourceURL: "https://example.com"}
+
+	if _, err := NewSitemapDiscoveryFetcher(client, store).Fetch(context.Background(), cfg); err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if got := store.discovered["disc"]; got.Root != "https://example.com" || !slices.Equal(got.Sitemaps, []string{"https://example.com/news-sitemap.xml"}) {
+		t.Fatalf("unexpected persisted discovery %+v", got)
+	}
+
+	// A fresh fetcher, as after a restart, reuses the stored result instead of probing the site again.
+	probes := len(client.calls)
+	if _, err := NewSitemapDiscoveryFetcher(client, store).Fetch(context.Background(), cfg); err != nil {
+		t.Fatalf("Fetch after restart: %v", err)
+	}
+	if extra := client.calls[probes:]; len(extra) != 1 || extra[0] != "https://example.com/news-sitemap.xml" {
+		t.Fatalf("expected stored discovery to be reused, got %v", extra)
+	}
+}
+
+func TestSitemapDiscoveryFetcherChildMatch(t *testing.T) {
+	client := &fakeHTTPClient{
+		responses: map[string]fakeResponse{
+			"https://example.com/sitemap_index.xml": {
+				body: []byte(`<sitemapindex>
+  <sitemap><loc>https://example.com/sitemaps/pages.xml</loc></sitemap>
+  <sitemap><loc>https://example.com/sitemaps/breaking.xml</loc></sitemap>
+</sitemapindex>`),
+				statusCode: http.StatusOK,
+			},
+			"https://example.com/sitemaps/breaking.xml": {body: []byte(discoveryNewsLeaf), statusCode: http.StatusOK},
+		},
+	}
+	cfg := Provider{ID: "disc", Type: ProviderTypeSitemapDiscovery, SourceURL: "https://example.com"}
+
+	f := NewSitemapDiscoveryFetcher(client, nil).(*sitemapDiscoveryFetcher)
+	if _, err := f.sitemaps(context.Background(), cfg, nil); err == nil {
+		t.Fatalf("expected children without \"news\" in their path to be skipped by default")
+	}
+
+	for _, match := range []string{"latest, Breaking", "*"} {
+		cfg.Config = map[string]any{ConfigDiscoveryChildMatchKey: match}
+		f := NewSitemapDiscoveryFetcher(client, nil).(*sitemapDiscoveryFetcher)
+		sitemaps, err := f.sitemaps(context.Background(), cfg, nil)
+		if err != nil {
+			t.Fatalf("sitemaps with %q: %v", match, err)
+		}
+		if !slices.Equal(sitemaps, []string{"https://example.com/sitemaps/breaking.xml"}) {
+			t.Fatalf("unexpected discovered sitemaps with %q: %v", match, sitemaps)
+		}
+	}
+}
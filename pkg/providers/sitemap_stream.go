@@ -0,0 +1,154 @@
+package providers
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/samvad-hq/samvad-news-harvester/internal/domain"
+	"github.com/samvad-hq/samvad-news-harvester/pkg/httpclient"
+)
+
+const (
+	// ConfigSitemapMaxEntriesKey caps how many <url> or <sitemap> entries are read from a single sitemap.
+	ConfigSitemapMaxEntriesKey = "sitemap_max_entries"
+
+	// defaultSitemapMaxEntries matches the sitemap protocol's per-file limit.
+	defaultSitemapMaxEntries = 50000
+)
+
+// sitemapKind identifies a sitemap document by its root element.
+type sitemapKind int
+
+const (
+	sitemapKindURLSet sitemapKind = iota + 1
+	sitemapKindIndex
+)
+
+// sitemapContents holds the entries decoded from one sitemap: URLs for a <urlset>, Sitemaps for a <sitemapindex>.
+// Truncated reports that reading stopped at the entry cap with entries left unread.
+type sitemapContents[T any] struct {
+	Kind      sitemapKind
+	URLs      []T
+	Sitemaps  []sitemapIndexEntry
+	Truncated bool
+}
+
+// streamedSitemap is a sitemap fetched and decoded in a single pass.
+type streamedSitemap[T any] struct {
+	sitemapContents[T]
+	NotModified bool
+	Validators  domain.SourceValidators
+}
+
+// decodeSitemap collects the entries of a sitemap read from r. maxEntries <= 0 means unlimited.
+func decodeSitemap[T any](r io.Reader, maxEntries int) (sitemapContents[T], error) {
+	var out sitemapContents[T]
+	kind, truncated, err := streamSitemap(r, maxEntries,
+		func(entry T) { out.URLs = append(out.URLs, entry) },
+		func(entry sitemapIndexEntry) { out.Sitemaps = append(out.Sitemaps, entry) },
+	)
+	out.Kind, out.Truncated = kind, truncated
+	return out, err
+}
+
+// streamSitemap reads r token by token, detecting <urlset> vs <sitemapindex> from the root element, and
+// hands each <url> or <sitemap> entry to the matching callback as soon as it is decoded. Reading stops
+// after maxEntries entries when maxEntries is positive; truncated reports that an element followed them.
+func streamSitemap[T any](r io.Reader, maxEntries int, onURL func(T), onSitemap func(sitemapIndexEntry)) (kind sitemapKind, truncated bool, err error) {
+	dec := xml.NewDecoder(r)
+
+	count := 0
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			if kind == 0 {
+				return 0, false, errors.New("sitemap has no root element")
+			}
+			return kind, false, nil
+		}
+		if err != nil {
+			return kind, false, err
+		}
+
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		if kind == 0 {
+			switch strings.ToLower(start.Name.Local) {
+			case "urlset":
+				kind = sitemapKindURLSet
+			case "sitemapindex":
+				kind = sitemapKindIndex
+			default:
+				return 0, false, fmt.Errorf("unexpected sitemap root element <%s>", start.Name.Local)
+			}
+			continue
+		}
+
+		if maxEntries > 0 && count >= maxEntries {
+			return kind, true, nil
+		}
+
+		switch {
+		case kind == sitemapKindURLSet && start.Name.Local == "url":
+			var entry T
+			if err := dec.DecodeElement(&entry, &start); err != nil {
+				return kind, false, err
+			}
+			count++
+			onURL(entry)
+		case kind == sitemapKindIndex && start.Name.Local == "sitemap":
+			var entry sitemapIndexEntry
+			if err := dec.DecodeElement(&entry, &start); err != nil {
+				return kind, false, err
+			}
+			entry.Loc = strings.TrimSpace(entry.Loc)
+			entry.LastMod = strings.TrimSpace(entry.LastMod)
+			if entry.Loc == "" {
+				continue
+			}
+			count++
+			onSitemap(entry)
+		default:
+			if err := dec.Skip(); err != nil {
+				return kind, false, err
+			}
+		}
+	}
+}
+
+// streamSitemapDocument fetches url and decodes it in a single pass without buffering the whole body.
+// A 304 response yields a result with NotModified set and the validators the request was sent with.
+func streamSitemapDocument[T any](ctx context.Context, client httpclient.Client, url string, cfg Provider, headers map[string]string, maxEntries int) (streamedSitemap[T], error) {
+	target, headers := Authorize(cfg, url, headers)
+	resp, err := client.Stream(ctx, httpclient.Request{Method: http.MethodGet, URL: target, Headers: headers})
+	if err != nil {
+		return streamedSitemap[T]{}, fmt.Errorf("fetch %s sitemap: %w", cfg.ID, RedactError(cfg, err))
+	}
+	defer resp.Close()
+
+	if resp.StatusCode() == http.StatusNotModified {
+		return streamedSitemap[T]{NotModified: true, Validators: requestValidators(headers)}, nil
+	}
+	if resp.StatusCode() != http.StatusOK {
+		snippet, _ := io.ReadAll(io.LimitReader(resp, 1024))
+		return streamedSitemap[T]{}, fmt.Errorf("%s sitemap returned status %d body: %s", cfg.ID, resp.StatusCode(), responseSnippet(snippet))
+	}
+
+	body, err := openXMLStream(resp, resp.ContentType(), cfg.ResponseFormat)
+	if err != nil {
+		return streamedSitemap[T]{}, fmt.Errorf("decode %s sitemap: %w", cfg.ID, err)
+	}
+	contents, err := decodeSitemap[T](body, maxEntries)
+	if err != nil {
+		return streamedSitemap[T]{}, fmt.Errorf("decode sitemap: %w", err)
+	}
+	return streamedSitemap[T]{sitemapContents: contents, Validators: responseValidators(resp.Header())}, nil
+}
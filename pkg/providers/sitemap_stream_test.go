@@ -0,0 +1,89 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestDecodeSitemapDetectsRootElement(t *testing.T) {
+	urlset := `<?xml version="1.0"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9" xmlns:xhtml="http://www.w3.org/1999/xhtml">
+  <url><loc>https://example.com/a</loc><xhtml:link rel="alternate" href="https://example.com/hi/a"/></url>
+  <url><loc>https://example.com/b</loc><lastmod>2024-01-01</lastmod></url>
+</urlset>`
+	contents, err := decodeSitemap[sitemapURL](strings.NewReader(urlset), 0)
+	if err != nil {
+		t.Fatalf("decodeSitemap urlset: %v", err)
+	}
+	if contents.Kind != sitemapKindURLSet || len(contents.URLs) != 2 || contents.URLs[1].LastMod != "2024-01-01" {
+		t.Fatalf("unexpected urlset contents %#v", contents)
+	}
+
+	index := `<sitemapindex><sitemap><loc> https://example.com/s1.xml </loc><lastmod>2024-02-01</lastmod></sitemap></sitemapindex>`
+	contents, err = decodeSitemap[sitemapURL](strings.NewReader(index), 0)
+	if err != nil {
+		t.Fatalf("decodeSitemap index: %v", err)
+	}
+	if contents.Kind != sitemapKindIndex || len(contents.Sitemaps) != 1 || contents.Sitemaps[0].Loc != "https://example.com/s1.xml" {
+		t.Fatalf("unexpected index contents %#v", contents)
+	}
+
+	if _, err := decodeSitemap[sitemapURL](strings.NewReader(`<rss><channel/></rss>`), 0); err == nil {
+		t.Fatalf("expected error for non-sitemap root")
+	}
+}
+
+func TestStreamSitemapStopsAtMaxEntries(t *testing.T) {
+	var buf bytes.Buffer
+	buf.WriteString("<urlset>")
+	for range 100 {
+		buf.WriteString("<url><loc>https://example.com/x</loc></url>")
+	}
+	// Anything after the cap is never read, so trailing garbage must not fail the decode.
+	buf.WriteString("<url><loc>broken")
+
+	seen := 0
+	kind, truncated, err := streamSitemap(&buf, 10, func(sitemapURL) { seen++ }, func(sitemapIndexEntry) {})
+	if err != nil {
+		t.Fatalf("streamSitemap: %v", err)
+	}
+	if kind != sitemapKindURLSet || seen != 10 || !truncated {
+		t.Fatalf("expected 10 entries from a truncated urlset, got %d (kind %d, truncated %v)", seen, kind, truncated)
+	}
+}
+
+func TestWalkerAppliesSitemapMaxEntries(t *testing.T) {
+	leaf := `<urlset>` + strings.Repeat(`<url><loc>https://example.com/a</loc><news><title>t</title></news></url>`, 5) + `</urlset>`
+	client := &fakeHTTPClient{responses: map[string]fakeResponse{
+		"https://example.com/news.xml": {body: gzipBytes(t, []byte(leaf)), statusCode: http.StatusOK},
+	}}
+	cfg := Provider{ID: "p1", Config: map[string]any{ConfigSitemapMaxEntriesKey: 3}}
+
+	walker := newSitemapWalker[googleNewsURL](client, memoryValidatorStore{}, cfg, nil)
+	urls, err := walker.walk(context.Background(), "https://example.com/news.xml")
+	if err != nil {
+		t.Fatalf("walk: %v", err)
+	}
+	if len(urls) != 3 {
+		t.Fatalf("expected 3 capped entries, got %d", len(urls))
+	}
+	if got := walker.limitStats()[FetchStatSitemapEntriesCapped]; got != 1 {
+		t.Fatalf("expected 1 entry-capped sitemap, got %d", got)
+	}
+	if v := walker.validators(); len(v) != 0 {
+		t.Fatalf("validators of a truncated sitemap must not be held, got %v", v)
+	}
+
+	// A sitemap holding exactly sitemap_max_entries entries is read to the end.
+	cfg.Config[ConfigSitemapMaxEntriesKey] = 5
+	walker = newSitemapWalker[googleNewsURL](client, memoryValidatorStore{}, cfg, nil)
+	if _, err := walker.walk(context.Background(), "https://example.com/news.xml"); err != nil {
+		t.Fatalf("walk: %v", err)
+	}
+	if got := walker.limitStats()[FetchStatSitemapEntriesCapped]; got != 0 {
+		t.Fatalf("expected no entry-capped sitemaps, got %d", got)
+	}
+}
@@ -0,0 +1,269 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/samvad-hq/samvad-news-harvester/internal/domain"
+	"github.com/samvad-hq/samvad-news-harvester/pkg/httpclient"
+)
+
+// Config keys bounding sitemap index traversal.
+const (
+	// ConfigSitemapMaxDepthKey limits how many levels of nested sitemap indexes are followed below source_url.
+	ConfigSitemapMaxDepthKey = "sitemap_max_depth"
+	// ConfigSitemapMaxChildrenKey limits how many children of a single index are fetched (most recent lastmod
+	// first, children without lastmod last).
+	ConfigSitemapMaxChildrenKey = "sitemap_max_children"
+	// ConfigSitemapConcurrencyKey limits how many sitemaps are fetched in parallel.
+	ConfigSitemapConcurrencyKey = "sitemap_concurrency"
+	// ConfigSitemapChildWindowKey skips index children whose <lastmod> is older than this window.
+	ConfigSitemapChildWindowKey = "sitemap_child_window"
+
+	defaultSitemapMaxDepth    = 3
+	defaultSitemapMaxChildren = 50
+	defaultSitemapConcurrency = 4
+	defaultSitemapChildWindow = 72 * time.Hour
+)
+
+// Fetch stats reported by sitemap fetchers for index children a walk did not follow and sitemaps it did
+// not read to the end.
+const (
+	// FetchStatSitemapsDepthLimited counts children of indexes found at sitemap_max_depth.
+	FetchStatSitemapsDepthLimited = "sitemaps_depth_limited"
+	// FetchStatSitemapsCapped counts children dropped by the sitemap_max_children fan-out cap.
+	FetchStatSitemapsCapped = "sitemaps_capped"
+	// FetchStatSitemapEntriesCapped counts sitemaps whose entries past sitemap_max_entries were not read.
+	FetchStatSitemapEntriesCapped = "sitemap_entries_capped"
+)
+
+// sitemapWalker streams sitemaps, decoding the <url> entries of each <urlset> into T and following
+// <sitemapindex> documents. Leaf sitemaps are fetched conditionally when validators are stored; the
+// validators they answer with are collected for the fetcher to hold until its run is committed.
+// Index children are fetched concurrently, bounded by the provider's depth, fan-out and freshness limits.
+type sitemapWalker[T any] struct {
+	client      httpclient.Client
+	store       ValidatorStore
+	cfg         Provider
+	headers     map[string]string
+	maxDepth    int
+	maxChildren int
+	maxEntries  int
+	since       time.Time
+	loc         *time.Location
+	slots       chan struct{}
+
+	mu      sync.Mutex
+	visited map[string]struct{}
+	// unchanged counts leaf sitemaps that answered 304 Not Modified.
+	unchanged int
+	// leaves counts leaf sitemaps that were fetched successfully (including 304s).
+	leaves int
+	// depthLimited counts index children skipped because their index sits at maxDepth.
+	depthLimited int
+	// capped counts index children dropped by the fan-out limit.
+	capped int
+	// entriesCapped counts sitemaps truncated at maxEntries.
+	entriesCapped int
+	// failures records nested sitemaps that could not be fetched or parsed.
+	failures []SourceFailure
+	// fetched holds the validators of leaf sitemaps fetched in full or confirmed by a 304.
+	fetched map[string]domain.SourceValidators
+}
+
+// newSitemapWalker builds a walker for a single provider run, reading traversal limits from cfg.
+func newSitemapWalker[T any](client httpclient.Client, store ValidatorStore, cfg Provider, headers map[string]string) *sitemapWalker[T] {
+	window := ConfigDuration(cfg, ConfigSitemapChildWindowKey, defaultSitemapChildWindow)
+	return &sitemapWalker[T]{
+		client:      client,
+		store:       store,
+		cfg:         cfg,
+		headers:     headers,
+		maxDepth:    ConfigInt(cfg, ConfigSitemapMaxDepthKey, defaultSitemapMaxDepth),
+		maxChildren: ConfigInt(cfg, ConfigSitemapMaxChildrenKey, defaultSitemapMaxChildren),
+		maxEntries:  ConfigInt(cfg, ConfigSitemapMaxEntriesKey, defaultSitemapMaxEntries),
+		since:       time.Now().Add(-window),
+		loc:         ProviderLocation(cfg),
+		slots:       make(chan struct{}, ConfigInt(cfg, ConfigSitemapConcurrencyKey, defaultSitemapConcurrency)),
+		visited:     make(map[string]struct{}),
+		fetched:     make(map[string]domain.SourceValidators),
+	}
+}
+
+// walk resolves url into leaf entries, recursing through sitemap indexes. visited guards against index cycles.
+func (w *sitemapWalker[T]) walk(ctx context.Context, url string) ([]T, error) {
+	return w.walkAt(ctx, url, 0)
+}
+
+// walkAt resolves a sitemap found depth index levels below the provider's source_url.
+func (w *sitemapWalker[T]) walkAt(ctx context.Context, url string, depth int) ([]T, error) {
+	if !w.visit(url) {
+		return nil, nil
+	}
+
+	doc, err := w.fetch(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	if doc.NotModified {
+		w.mu.Lock()
+		w.unchanged++
+		w.leaves++
+		w.fetched[url] = doc.Validators
+		w.mu.Unlock()
+		return nil, nil
+	}
+
+	if doc.Truncated {
+		w.mu.Lock()
+		w.entriesCapped++
+		w.mu.Unlock()
+	}
+
+	if doc.Kind == sitemapKindURLSet {
+		w.mu.Lock()
+		w.leaves++
+		// Only leaves are cached: an unchanged index can still point at children whose content changed. A
+		// truncated leaf is not, or its unread entries would never be fetched once the sitemap shrinks.
+		if !doc.Truncated {
+			w.fetched[url] = doc.Validators
+		}
+		w.mu.Unlock()
+		return doc.URLs, nil
+	}
+
+	if depth >= w.maxDepth {
+		w.mu.Lock()
+		w.depthLimited += len(doc.Sitemaps)
+		w.mu.Unlock()
+		return nil, nil
+	}
+	children := w.selectChildren(doc.Sitemaps)
+	if len(children) == 0 {
+		return nil, nil
+	}
+
+	results := make([][]T, len(children))
+	errs := make([]error, len(children))
+	var wg sync.WaitGroup
+	for i, child := range children {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = w.walkAt(ctx, child.Loc, depth+1)
+		}()
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var all []T
+	for i, child := range children {
+		if errs[i] != nil {
+			// One broken child must not discard what its siblings produced.
+			w.fail(SourceFailure{URL: child.Loc, Err: errs[i]})
+			continue
+		}
+		all = append(all, results[i]...)
+	}
+	return all, nil
+}
+
+// selectChildren drops index children whose lastmod is outside the freshness window and, when more remain
+// than the fan-out limit, keeps the most recently modified ones. Children without lastmod pass the window
+// but rank after every dated child, in index order, so they are the first to go when the cap applies.
+func (w *sitemapWalker[T]) selectChildren(index []sitemapIndexEntry) []sitemapIndexEntry {
+	fresh := make([]sitemapIndexEntry, 0, len(index))
+	for _, child := range index {
+		if lastMod, ok := ParseDate(child.LastMod, w.loc); ok && lastMod.Before(w.since) {
+			continue
+		}
+		fresh = append(fresh, child)
+	}
+	if len(fresh) <= w.maxChildren {
+		return fresh
+	}
+
+	slices.SortStableFunc(fresh, func(a, b sitemapIndexEntry) int {
+		lastA, _ := ParseDate(a.LastMod, w.loc)
+		lastB, _ := ParseDate(b.LastMod, w.loc)
+		return lastB.Compare(lastA)
+	})
+	w.mu.Lock()
+	w.capped += len(fresh) - w.maxChildren
+	w.mu.Unlock()
+	return fresh[:w.maxChildren]
+}
+
+// visit marks url as seen and reports whether it was new.
+func (w *sitemapWalker[T]) visit(url string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, seen := w.visited[url]; seen {
+		return false
+	}
+	w.visited[url] = struct{}{}
+	return true
+}
+
+// fetch streams and decodes a sitemap once a concurrency slot is available.
+func (w *sitemapWalker[T]) fetch(ctx context.Context, url string) (streamedSitemap[T], error) {
+	select {
+	case w.slots <- struct{}{}:
+	case <-ctx.Done():
+		return streamedSitemap[T]{}, ctx.Err()
+	}
+	defer func() { <-w.slots }()
+
+	return streamSitemapDocument[T](ctx, w.client, url, w.cfg, conditionalHeaders(w.store, url, w.headers), w.maxEntries)
+}
+
+// fail records a sitemap that could not be fetched or parsed.
+func (w *sitemapWalker[T]) fail(f SourceFailure) {
+	w.mu.Lock()
+	w.failures = append(w.failures, f)
+	w.mu.Unlock()
+}
+
+// failureErr reports nested sitemap failures after a walk: nil when there were none, a *PartialError when
+// at least one leaf sitemap succeeded, and an error joining every failure otherwise.
+func (w *sitemapWalker[T]) failureErr() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.failures) == 0 {
+		return nil
+	}
+	if w.leaves > 0 {
+		return &PartialError{Failures: w.failures}
+	}
+	errs := make([]error, 0, len(w.failures))
+	for _, f := range w.failures {
+		errs = append(errs, fmt.Errorf("%s: %w", f.URL, f.Err))
+	}
+	return fmt.Errorf("%s every nested sitemap failed: %w", w.cfg.ID, errors.Join(errs...))
+}
+
+// limitStats returns the fetch stats counting index children the walk did not follow and truncated sitemaps.
+func (w *sitemapWalker[T]) limitStats() map[string]int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return map[string]int{
+		FetchStatSitemapsDepthLimited: w.depthLimited,
+		FetchStatSitemapsCapped:       w.capped,
+		FetchStatSitemapEntriesCapped: w.entriesCapped,
+	}
+}
+
+// validators returns the validators of the leaf sitemaps fetched in full during the walk.
+func (w *sitemapWalker[T]) validators() map[string]domain.SourceValidators {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return maps.Clone(w.fetched)
+}
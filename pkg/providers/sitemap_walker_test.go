@@ -0,0 +1,131 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/samvad-hq/samvad-news-harvester/pkg/httpclient"
+)
+
+func newsLeaf(loc string) []byte {
+	return []byte(fmt.Sprintf(`<urlset><url><loc>%s</loc><news><title>t</title></news></url></urlset>`, loc))
+}
+
+func TestSitemapWalkerSkipsStaleAndLimitsChildren(t *testing.T) {
+	now := time.Now().UTC()
+	recent := now.Add(-time.Hour).Format(time.RFC3339)
+	older := now.Add(-2 * time.Hour).Format(time.RFC3339)
+	stale := now.Add(-30 * 24 * time.Hour).Format("2006-01-02")
+
+	index := fmt.Sprintf(`<sitemapindex>
+  <sitemap><loc>https://example.com/archive-2019.xml</loc><lastmod>%s</lastmod></sitemap>
+  <sitemap><loc>https://example.com/older.xml</loc><lastmod>%s</lastmod></sitemap>
+  <sitemap><loc>https://example.com/recent.xml</loc><lastmod>%s</lastmod></sitemap>
+  <sitemap><loc>https://example.com/undated.xml</loc></sitemap>
+</sitemapindex>`, stale, older, recent)
+
+	client := &fakeHTTPClient{responses: map[string]fakeResponse{
+		"https://example.com/index.xml":        {body: []byte(index), statusCode: http.StatusOK},
+		"https://example.com/archive-2019.xml": {body: newsLeaf("https://example.com/old"), statusCode: http.StatusOK},
+		"https://example.com/older.xml":        {body: newsLeaf("https://example.com/older"), statusCode: http.StatusOK},
+		"https://example.com/recent.xml":       {body: newsLeaf("https://example.com/recent"), statusCode: http.StatusOK},
+		"https://example.com/undated.xml":      {body: newsLeaf("https://example.com/undated"), statusCode: http.StatusOK},
+	}}
+	cfg := Provider{ID: "p1", Config: map[string]any{ConfigSitemapMaxChildrenKey: 2}}
+
+	walker := newSitemapWalker[googleNewsURL](client, nil, cfg, nil)
+	urls, err := walker.walk(context.Background(), "https://example.com/index.xml")
+	if err != nil {
+		t.Fatalf("walk: %v", err)
+	}
+
+	var locs []string
+	for _, u := range urls {
+		locs = append(locs, u.Loc)
+	}
+	if want := []string{"https://example.com/recent", "https://example.com/older"}; !slices.Equal(locs, want) {
+		t.Fatalf("got %v, want %v", locs, want)
+	}
+	if slices.Contains(client.calls, "https://example.com/archive-2019.xml") {
+		t.Fatalf("stale child should not be fetched")
+	}
+	// The undated child ranks last, so the cap drops it.
+	if stats := walker.limitStats(); stats[FetchStatSitemapsCapped] != 1 || stats[FetchStatSitemapsDepthLimited] != 0 {
+		t.Fatalf("unexpected limit stats %v", stats)
+	}
+}
+
+func TestSitemapWalkerRespectsMaxDepth(t *testing.T) {
+	client := &fakeHTTPClient{responses: map[string]fakeResponse{
+		"https://example.com/a.xml":    {body: []byte(`<sitemapindex><sitemap><loc>https://example.com/b.xml</loc></sitemap></sitemapindex>`), statusCode: http.StatusOK},
+		"https://example.com/b.xml":    {body: []byte(`<sitemapindex><sitemap><loc>https://example.com/leaf.xml</loc></sitemap></sitemapindex>`), statusCode: http.StatusOK},
+		"https://example.com/leaf.xml": {body: newsLeaf("https://example.com/x"), statusCode: http.StatusOK},
+	}}
+
+	shallow := newSitemapWalker[googleNewsURL](client, nil, Provider{ID: "p1", Config: map[string]any{ConfigSitemapMaxDepthKey: 1}}, nil)
+	if urls, err := shallow.walk(context.Background(), "https://example.com/a.xml"); err != nil || len(urls) != 0 {
+		t.Fatalf("expected depth limit to stop before leaf, got %v, %v", urls, err)
+	}
+	if stats := shallow.limitStats(); stats[FetchStatSitemapsDepthLimited] != 1 {
+		t.Fatalf("expected the skipped subtree to be counted, got %v", stats)
+	}
+
+	deep := newSitemapWalker[googleNewsURL](client, nil, Provider{ID: "p1", Config: map[string]any{ConfigSitemapMaxDepthKey: "2"}}, nil)
+	if urls, err := deep.walk(context.Background(), "https://example.com/a.xml"); err != nil || len(urls) != 1 {
+		t.Fatalf("expected leaf at depth 2, got %v, %v", urls, err)
+	}
+}
+
+// countingClient tracks the peak number of in-flight requests.
+type countingClient struct {
+	*fakeHTTPClient
+	mu       sync.Mutex
+	inFlight int
+	peak     int
+}
+
+func (c *countingClient) Stream(ctx context.Context, req httpclient.Request) (httpclient.StreamResponse, error) {
+	c.mu.Lock()
+	c.inFlight++
+	c.peak = max(c.peak, c.inFlight)
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		c.inFlight--
+		c.mu.Unlock()
+	}()
+
+	time.Sleep(5 * time.Millisecond)
+	return c.fakeHTTPClient.Stream(ctx, req)
+}
+
+func TestSitemapWalkerBoundsConcurrency(t *testing.T) {
+	responses := map[string]fakeResponse{}
+	index := "<sitemapindex>"
+	for i := range 8 {
+		loc := fmt.Sprintf("https://example.com/s%d.xml", i)
+		index += "<sitemap><loc>" + loc + "</loc></sitemap>"
+		responses[loc] = fakeResponse{body: newsLeaf(fmt.Sprintf("https://example.com/a%d", i)), statusCode: http.StatusOK}
+	}
+	responses["https://example.com/index.xml"] = fakeResponse{body: []byte(index + "</sitemapindex>"), statusCode: http.StatusOK}
+
+	client := &countingClient{fakeHTTPClient: &fakeHTTPClient{responses: responses}}
+	cfg := Provider{ID: "p1", Config: map[string]any{ConfigSitemapConcurrencyKey: 3}}
+	walker := newSitemapWalker[googleNewsURL](client, nil, cfg, nil)
+
+	urls, err := walker.walk(context.Background(), "https://example.com/index.xml")
+	if err != nil {
+		t.Fatalf("walk: %v", err)
+	}
+	if len(urls) != 8 || urls[0].Loc != "https://example.com/a0" || urls[7].Loc != "https://example.com/a7" {
+		t.Fatalf("expected 8 urls in index order, got %v", urls)
+	}
+	if peak := client.peak; peak > 3 || peak < 2 {
+		t.Fatalf("expected at most 3 concurrent fetches, peak was %d", peak)
+	}
+}
@@ -7,8 +7,8 @@ import (
 	"encoding/xml"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
-	"time"
 
 	"github.com/samvad-hq/samvad-news-harvester/internal/domain"
 	"github.com/samvad-hq/samvad-news-harvester/pkg/httpclient"
@@ -33,62 +33,109 @@ func responseSnippet(body []byte) string {
 	return s
 }
 
-type googleNewsSitemap struct {
-	URLs []googleNewsURL `xml:"url"`
-}
-
+// googleNewsURL is a <url> entry of a Google News sitemap. The news and image extensions are matched by
+// local name; applyNamespaceMode decides afterwards which namespaces are accepted.
 type googleNewsURL struct {
-	Loc    string            `xml:"loc"`
-	News   googleNewsDetail  `xml:"http://www.google.com/schemas/sitemap-news/0.9 news"`
-	Images []googleNewsImage `xml:"http://www.google.com/schemas/sitemap-image/1.1 image"`
-}
-
-type sitemapIndex struct {
-	Sitemaps []sitemapIndexEntry `xml:"sitemap"`
+	Loc        string            `xml:"loc"`
+	News       googleNewsDetail  `xml:"news"`
+	Images     []googleNewsImage `xml:"image"`
+	Alternates []xhtmlLink       `xml:"link"`
 }
 
 type sitemapIndexEntry struct {
-	Loc string `xml:"loc"`
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod"`
 }
 
 type googleNewsDetail struct {
-	PublicationDate string `xml:"http://www.google.com/schemas/sitemap-news/0.9 publication_date"`
-	Keywords        string `xml:"http://www.google.com/schemas/sitemap-news/0.9 keywords"`
-	Title           string `xml:"http://www.google.com/schemas/sitemap-news/0.9 title"`
+	XMLName         xml.Name
+	Publication     googleNewsPublication `xml:"publication"`
+	Access          string                `xml:"access"`
+	Genres          string                `xml:"genres"`
+	PublicationDate string                `xml:"publication_date"`
+	Keywords        string                `xml:"keywords"`
+	StockTickers    string                `xml:"stock_tickers"`
+	Title           string                `xml:"title"`
+}
+
+type googleNewsPublication struct {
+	Name     string `xml:"name"`
+	Language string `xml:"language"`
 }
 
 type googleNewsImage struct {
-	Loc   string `xml:"http://www.google.com/schemas/sitemap-image/1.1 loc"`
-	Title string `xml:"http://www.google.com/schemas/sitemap-image/1.1 title"`
+	XMLName xml.Name
+	Loc     string `xml:"loc"`
+	Title   string `xml:"title"`
+	Caption string `xml:"caption"`
 }
 
-// parseGoogleNewsSitemap parses the XML data into a slice of googleNewsURL structs.
-func parseGoogleNewsSitemap(data []byte) ([]googleNewsURL, error) {
-	var sitemap googleNewsSitemap
-	if err := xml.Unmarshal(data, &sitemap); err != nil {
-		return nil, err
-	}
-	return sitemap.URLs, nil
+// videoSitemapURL is a <url> entry of a video sitemap; it may also carry news metadata.
+type videoSitemapURL struct {
+	Loc        string           `xml:"loc"`
+	Videos     []videoEntry     `xml:"video"`
+	News       googleNewsDetail `xml:"news"`
+	Alternates []xhtmlLink      `xml:"link"`
 }
 
-// parseSitemapIndex parses an XML sitemap index file and returns the nested sitemap URLs.
-func parseSitemapIndex(data []byte) ([]string, error) {
-	var index sitemapIndex
-	if err := xml.Unmarshal(data, &index); err != nil {
-		return nil, err
-	}
+type videoEntry struct {
+	ThumbnailLoc    string   `xml:"thumbnail_loc"`
+	Title           string   `xml:"title"`
+	Description     string   `xml:"description"`
+	ContentLoc      string   `xml:"content_loc"`
+	PlayerLoc       string   `xml:"player_loc"`
+	Duration        string   `xml:"duration"`
+	PublicationDate string   `xml:"publication_date"`
+	Tags            []string `xml:"tag"`
+	Live            string   `xml:"live"`
+}
+
+// xhtmlLink is an <xhtml:link rel="alternate" hreflang="..." href="..."/> language alternate.
+type xhtmlLink struct {
+	Rel      string `xml:"rel,attr"`
+	Hreflang string `xml:"hreflang,attr"`
+	Href     string `xml:"href,attr"`
+}
+
+// buildArticlesFromSitemap constructs domain.Article instances from parsed Google News sitemap URLs.
+func buildArticlesFromSitemap(providerID string, urls []googleNewsURL, dates *dateParser) []domain.Article {
+	articles := make([]domain.Article, 0, len(urls))
+	for _, entry := range urls {
+		loc := strings.TrimSpace(entry.Loc)
+		if loc == "" {
+			continue
+		}
 
-	urls := make([]string, 0, len(index.Sitemaps))
-	for _, entry := range index.Sitemaps {
-		if loc := strings.TrimSpace(entry.Loc); loc != "" {
-			urls = append(urls, loc)
+		news := entry.News
+		images := sitemapImages(entry.Images)
+		var imageURL string
+		if len(images) > 0 {
+			imageURL = images[0].URL
 		}
+
+		articles = append(articles, domain.Article{
+			ProviderID:      providerID,
+			ID:              hashURL(loc),
+			Title:           strings.TrimSpace(news.Title),
+			URL:             loc,
+			ImageURL:        imageURL,
+			Keywords:        parseKeywords(news.Keywords),
+			PublishedAt:     dates.parse(news.PublicationDate),
+			PublicationName: strings.TrimSpace(news.Publication.Name),
+			Language:        strings.TrimSpace(news.Publication.Language),
+			Genres:          parseKeywords(news.Genres),
+			StockTickers:    parseKeywords(news.StockTickers),
+			Access:          strings.TrimSpace(news.Access),
+			Images:          images,
+			Alternates:      alternateLinks(entry.Alternates),
+		})
 	}
-	return urls, nil
+	return articles
 }
 
-// buildArticlesFromSitemap constructs domain.Article instances from parsed Google News sitemap URLs.
-func buildArticlesFromSitemap(providerID string, urls []googleNewsURL) []domain.Article {
+// buildArticlesFromVideoSitemap constructs video articles from parsed video sitemap URLs. The first video
+// supplies the article title, description, thumbnail and date, falling back to any news metadata.
+func buildArticlesFromVideoSitemap(providerID string, urls []videoSitemapURL, dates *dateParser) []domain.Article {
 	articles := make([]domain.Article, 0, len(urls))
 	for _, entry := range urls {
 		loc := strings.TrimSpace(entry.Loc)
@@ -96,32 +143,94 @@ func buildArticlesFromSitemap(providerID string, urls []googleNewsURL) []domain.
 			continue
 		}
 
-		keywords := parseKeywords(entry.News.Keywords)
-		publishedAt := parsePublicationDate(entry.News.PublicationDate)
-		title := strings.TrimSpace(entry.News.Title)
-		imageURL := firstImageURL(entry.Images)
+		videos := make([]domain.Video, 0, len(entry.Videos))
+		for _, v := range entry.Videos {
+			videos = append(videos, buildVideo(v, dates))
+		}
+		if len(videos) == 0 {
+			continue
+		}
+		primary := videos[0]
+
+		published := primary.PublishedAt
+		if published.IsZero() {
+			published = dates.parse(entry.News.PublicationDate)
+		}
 
 		articles = append(articles, domain.Article{
-			ProviderID:  providerID,
-			ID:          hashURL(loc),
-			Title:       title,
-			URL:         loc,
-			ImageURL:    imageURL,
-			Keywords:    keywords,
-			PublishedAt: publishedAt,
+			ProviderID:      providerID,
+			ID:              hashURL(loc),
+			Title:           firstNonBlank(primary.Title, entry.News.Title),
+			URL:             loc,
+			Description:     primary.Description,
+			ImageURL:        primary.ThumbnailURL,
+			Keywords:        firstNonEmptyList(parseKeywords(entry.News.Keywords), primary.Tags),
+			PublishedAt:     published,
+			PublicationName: strings.TrimSpace(entry.News.Publication.Name),
+			Language:        strings.TrimSpace(entry.News.Publication.Language),
+			Alternates:      alternateLinks(entry.Alternates),
+			MediaType:       domain.MediaTypeVideo,
+			Videos:          videos,
 		})
 	}
 	return articles
 }
 
-// firstImageURL returns the first non-empty image URL from the list.
-func firstImageURL(images []googleNewsImage) string {
+// buildVideo converts a video:video element into domain metadata.
+func buildVideo(v videoEntry, dates *dateParser) domain.Video {
+	duration, _ := strconv.Atoi(strings.TrimSpace(v.Duration))
+	return domain.Video{
+		Title:           strings.TrimSpace(v.Title),
+		Description:     strings.TrimSpace(v.Description),
+		ThumbnailURL:    strings.TrimSpace(v.ThumbnailLoc),
+		ContentURL:      strings.TrimSpace(v.ContentLoc),
+		PlayerURL:       strings.TrimSpace(v.PlayerLoc),
+		DurationSeconds: max(duration, 0),
+		PublishedAt:     dates.parse(v.PublicationDate),
+		Tags:            trimmedValues(v.Tags),
+		Live:            strings.EqualFold(strings.TrimSpace(v.Live), "yes"),
+	}
+}
+
+// firstNonEmptyList returns the first list with at least one element.
+func firstNonEmptyList(lists ...[]string) []string {
+	for _, l := range lists {
+		if len(l) > 0 {
+			return l
+		}
+	}
+	return nil
+}
+
+// sitemapImages converts image:image entries with a location, keeping their titles and captions.
+func sitemapImages(images []googleNewsImage) []domain.Image {
+	var out []domain.Image
 	for _, img := range images {
-		if loc := strings.TrimSpace(img.Loc); loc != "" {
-			return loc
+		loc := strings.TrimSpace(img.Loc)
+		if loc == "" {
+			continue
+		}
+		out = append(out, domain.Image{
+			URL:     loc,
+			Title:   strings.TrimSpace(img.Title),
+			Caption: strings.TrimSpace(img.Caption),
+		})
+	}
+	return out
+}
+
+// alternateLinks keeps xhtml:link entries that declare a language alternate.
+func alternateLinks(links []xhtmlLink) []domain.AlternateLink {
+	var out []domain.AlternateLink
+	for _, link := range links {
+		href := strings.TrimSpace(link.Href)
+		lang := strings.TrimSpace(link.Hreflang)
+		if href == "" || lang == "" || !strings.EqualFold(strings.TrimSpace(link.Rel), "alternate") {
+			continue
 		}
+		out = append(out, domain.AlternateLink{Language: lang, URL: href})
 	}
-	return ""
+	return out
 }
 
 // parseKeywords splits a comma-separated string of keywords into a slice of trimmed strings.
@@ -145,31 +254,40 @@ func parseKeywords(raw string) []string {
 	return keywords
 }
 
-// parsePublicationDate attempts to parse the publication date from a string.
-func parsePublicationDate(raw string) time.Time {
-	raw = strings.TrimSpace(raw)
-	if raw == "" {
-		return time.Time{}
-	}
-
-	if t, err := time.Parse(time.RFC3339, raw); err == nil {
-		return t.UTC()
-	}
-
-	return time.Time{}
+// document is a fetched and decoded provider source.
+type document struct {
+	Body        []byte
+	NotModified bool
+	Validators  domain.SourceValidators
+	Header      http.Header
 }
 
-// fetchSitemap retrieves the sitemap XML data from the given URL using the provided HTTP client.
-func fetchSitemap(ctx context.Context, client httpclient.Client, url, providerID string, headers map[string]string) ([]byte, error) {
-	resp, err := client.Get(ctx, url, headers)
+// fetchDocument retrieves a provider source and decodes it according to cfg.ResponseFormat.
+// kind labels errors (sitemap, feed, ...); want is the format the caller can parse.
+// A 304 response yields a document with NotModified set, no body and the validators the request was sent with.
+func fetchDocument(ctx context.Context, client httpclient.Client, url string, cfg Provider, kind, want string, headers map[string]string) (document, error) {
+	target, headers := Authorize(cfg, url, headers)
+	resp, err := client.Get(ctx, target, headers)
 	if err != nil {
-		return nil, fmt.Errorf("fetch %s sitemap: %w", providerID, err)
+		return document{}, fmt.Errorf("fetch %s %s: %w", cfg.ID, kind, RedactError(cfg, err))
+	}
+
+	if resp.StatusCode() == http.StatusNotModified {
+		return document{NotModified: true, Validators: requestValidators(headers)}, nil
 	}
 
 	body := resp.Body()
 	if resp.StatusCode() != http.StatusOK {
-		return nil, fmt.Errorf("%s sitemap returned status %d body: %s", providerID, resp.StatusCode(), responseSnippet(body))
+		return document{}, fmt.Errorf("%s %s returned status %d body: %s", cfg.ID, kind, resp.StatusCode(), responseSnippet(body))
+	}
+
+	decoded, format, err := decodeBody(body, resp.ContentType(), cfg.ResponseFormat)
+	if err != nil {
+		return document{}, fmt.Errorf("decode %s %s: %w", cfg.ID, kind, err)
+	}
+	if format != want {
+		return document{}, fmt.Errorf("%s %s is %s, expected %s", cfg.ID, kind, format, want)
 	}
 
-	return body, nil
+	return document{Body: decoded, Validators: responseValidators(resp.Header()), Header: resp.Header()}, nil
 }
@@ -1,10 +1,13 @@
 package providers
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"io"
 	"net/http"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -15,19 +18,42 @@ import (
 type fakeResponse struct {
 	body       []byte
 	statusCode int
+	header     http.Header
+	// url is the final URL after redirects.
+	url string
 }
 
-func (f fakeResponse) Body() []byte    { return f.body }
-func (f fakeResponse) StatusCode() int { return f.statusCode }
+func (f fakeResponse) Body() []byte         { return f.body }
+func (f fakeResponse) StatusCode() int      { return f.statusCode }
+func (f fakeResponse) Header() http.Header  { return f.header }
+func (f fakeResponse) URL() string          { return f.url }
+func (f fakeResponse) ContentType() string  { return f.header.Get("Content-Type") }
+func (f fakeResponse) ContentLength() int64 { return int64(len(f.body)) }
 
-// fakeHTTPClient returns canned responses per URL to avoid network calls.
+// fakeStream wraps a fakeResponse as a StreamResponse.
+type fakeStream struct {
+	fakeResponse
+	io.Reader
+}
+
+func (fakeStream) Close() error { return nil }
+
+// fakeHTTPClient returns canned responses per URL to avoid network calls. It is safe for concurrent use.
 type fakeHTTPClient struct {
+	mu        sync.Mutex
 	responses map[string]fakeResponse
 	calls     []string
+	headers   map[string]map[string]string
 }
 
-func (f *fakeHTTPClient) Get(_ context.Context, url string, _ map[string]string) (httpclient.Response, error) {
+func (f *fakeHTTPClient) Get(_ context.Context, url string, headers map[string]string) (httpclient.Response, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
 	f.calls = append(f.calls, url)
+	if f.headers == nil {
+		f.headers = make(map[string]map[string]string)
+	}
+	f.headers[url] = headers
 	resp, ok := f.responses[url]
 	if !ok {
 		return nil, errors.New("not found")
@@ -35,6 +61,18 @@ func (f *fakeHTTPClient) Get(_ context.Context, url string, _ map[string]string)
 	return resp, nil
 }
 
+func (f *fakeHTTPClient) Do(ctx context.Context, req httpclient.Request) (httpclient.Response, error) {
+	return f.Get(ctx, req.URL, req.Headers)
+}
+
+func (f *fakeHTTPClient) Stream(ctx context.Context, req httpclient.Request) (httpclient.StreamResponse, error) {
+	resp, err := f.Get(ctx, req.URL, req.Headers)
+	if err != nil {
+		return nil, err
+	}
+	return fakeStream{fakeResponse: resp.(fakeResponse), Reader: bytes.NewReader(resp.Body())}, nil
+}
+
 func TestParseGoogleNewsSitemap(t *testing.T) {
 	xml := []byte(`
 <urlset xmlns:news="http://www.google.com/schemas/sitemap-news/0.9" xmlns:image="http://www.google.com/schemas/sitemap-image/1.1">
@@ -54,15 +92,16 @@ func TestParseGoogleNewsSitemap(t *testing.T) {
   </url>
 </urlset>`)
 
-	entries, err := parseGoogleNewsSitemap(xml)
+	contents, err := decodeSitemap[googleNewsURL](bytes.NewReader(xml), 0)
 	if err != nil {
-		t.Fatalf("parseGoogleNewsSitemap: %v", err)
+		t.Fatalf("decodeSitemap: %v", err)
 	}
+	entries := contents.URLs
 	if len(entries) != 2 {
 		t.Fatalf("expected 2 url entries, got %d", len(entries))
 	}
 
-	articles := buildArticlesFromSitemap("provider-x", entries)
+	articles := buildArticlesFromSitemap("provider-x", entries, &dateParser{loc: time.UTC})
 	if len(articles) != 1 {
 		t.Fatalf("expected 1 article after filtering empty loc, got %d", len(articles))
 	}
@@ -88,7 +127,69 @@ func TestParseGoogleNewsSitemap(t *testing.T) {
 	}
 }
 
-func TestParseSitemapIndex(t *testing.T) {
+func TestBuildArticlesFromSitemapFullFields(t *testing.T) {
+	data := []byte(`
+<urlset xmlns:news="http://www.google.com/schemas/sitemap-news/0.9"
+        xmlns:image="http://www.google.com/schemas/sitemap-image/1.1"
+        xmlns:xhtml="http://www.w3.org/1999/xhtml">
+  <url>
+    <loc>https://example.com/hi/markets</loc>
+    <xhtml:link rel="alternate" hreflang="en" href="https://example.com/en/markets"/>
+    <xhtml:link rel="canonical" href="https://example.com/hi/markets"/>
+    <news:news>
+      <news:publication>
+        <news:name>Example Times</news:name>
+        <news:language>hi</news:language>
+      </news:publication>
+      <news:access>Subscription</news:access>
+      <news:genres>PressRelease, Blog</news:genres>
+      <news:publication_date>2024-01-01T00:00:00Z</news:publication_date>
+      <news:title>Markets</news:title>
+      <news:stock_tickers>NSE:INFY, BSE:500209</news:stock_tickers>
+    </news:news>
+    <image:image>
+      <image:loc>https://example.com/1.jpg</image:loc>
+      <image:title>Trading floor</image:title>
+      <image:caption>Traders at work</image:caption>
+    </image:image>
+    <image:image>
+      <image:loc>https://example.com/2.jpg</image:loc>
+    </image:image>
+  </url>
+</urlset>`)
+
+	contents, err := decodeSitemap[googleNewsURL](bytes.NewReader(data), 0)
+	if err != nil {
+		t.Fatalf("decodeSitemap: %v", err)
+	}
+	entries := contents.URLs
+	articles := buildArticlesFromSitemap("p", entries, &dateParser{loc: time.UTC})
+	if len(articles) != 1 {
+		t.Fatalf("expected 1 article, got %d", len(articles))
+	}
+
+	art := articles[0]
+	if art.PublicationName != "Example Times" || art.Language != "hi" || art.Access != "Subscription" {
+		t.Errorf("unexpected publication fields %+v", art)
+	}
+	if len(art.Genres) != 2 || art.Genres[1] != "Blog" {
+		t.Errorf("Genres = %#v", art.Genres)
+	}
+	if len(art.StockTickers) != 2 || art.StockTickers[0] != "NSE:INFY" {
+		t.Errorf("StockTickers = %#v", art.StockTickers)
+	}
+	if len(art.Images) != 2 || art.Images[0].Title != "Trading floor" || art.Images[0].Caption != "Traders at work" {
+		t.Errorf("Images = %#v", art.Images)
+	}
+	if art.ImageURL != "https://example.com/1.jpg" {
+		t.Errorf("ImageURL = %s", art.ImageURL)
+	}
+	if len(art.Alternates) != 1 || art.Alternates[0].Language != "en" || art.Alternates[0].URL != "https://example.com/en/markets" {
+		t.Errorf("Alternates = %#v", art.Alternates)
+	}
+}
+
+func TestDecodeSitemapIndex(t *testing.T) {
 	data := []byte(`
 <sitemapindex>
   <sitemap><loc>https://example.com/s1.xml</loc></sitemap>
@@ -96,10 +197,11 @@ func TestParseSitemapIndex(t *testing.T) {
   <sitemap><loc>https://example.com/s2.xml</loc></sitemap>
 </sitemapindex>`)
 
-	urls, err := parseSitemapIndex(data)
+	contents, err := decodeSitemap[googleNewsURL](bytes.NewReader(data), 0)
 	if err != nil {
-		t.Fatalf("parseSitemapIndex: %v", err)
+		t.Fatalf("decodeSitemap: %v", err)
 	}
+	urls := contents.Sitemaps
 	if len(urls) != 2 {
 		t.Fatalf("expected 2 urls, got %d", len(urls))
 	}
@@ -136,7 +238,7 @@ func TestFetchGoogleNewsURLsFollowsIndexes(t *testing.T) {
 		SourceURL: "https://example.com/root.xml",
 	}
 
-	urls, err := fetcher.fetchGoogleNewsURLs(context.Background(), cfg, cfg.SourceURL, nil, nil)
+	urls, _, _, err := fetcher.fetchGoogleNewsURLs(context.Background(), cfg, cfg.SourceURL, nil)
 	if err != nil {
 		t.Fatalf("fetchGoogleNewsURLs: %v", err)
 	}
@@ -151,6 +253,44 @@ func TestFetchGoogleNewsURLsFollowsIndexes(t *testing.T) {
 	}
 }
 
+func TestGoogleNewsFetcherKeepsPartialIndexResults(t *testing.T) {
+	indexXML := []byte(`
+<sitemapindex>
+  <sitemap><loc>https://example.com/politics.xml</loc></sitemap>
+  <sitemap><loc>https://example.com/sports.xml</loc></sitemap>
+  <sitemap><loc>https://example.com/missing.xml</loc></sitemap>
+</sitemapindex>`)
+	leafXML := []byte(`<urlset><url><loc>https://example.com/politics/1</loc><news><title>Vote</title></news></url></urlset>`)
+
+	client := &fakeHTTPClient{
+		responses: map[string]fakeResponse{
+			"https://example.com/root.xml":     {body: indexXML, statusCode: http.StatusOK},
+			"https://example.com/politics.xml": {body: leafXML, statusCode: http.StatusOK},
+			"https://example.com/sports.xml":   {body: []byte("oops"), statusCode: http.StatusInternalServerError},
+		},
+	}
+	cfg := Provider{ID: "p1", Type: ProviderTypeGoogleNews, SourceURL: "https://example.com/root.xml"}
+
+	articles, err := NewGoogleNewsFetcher(client, nil).Fetch(context.Background(), cfg)
+	partial, ok := IsPartial(err)
+	if !ok {
+		t.Fatalf("expected *PartialError, got %v", err)
+	}
+	if len(articles) != 1 || articles[0].URL != "https://example.com/politics/1" {
+		t.Fatalf("expected articles from the healthy child, got %#v", articles)
+	}
+	urls := partial.URLs()
+	if len(urls) != 2 || urls[0] != "https://example.com/sports.xml" || urls[1] != "https://example.com/missing.xml" {
+		t.Fatalf("unexpected failed sources %v", urls)
+	}
+
+	delete(client.responses, "https://example.com/politics.xml")
+	_, err = NewGoogleNewsFetcher(client, nil).Fetch(context.Background(), cfg)
+	if _, ok := IsPartial(err); err == nil || ok {
+		t.Fatalf("expected hard failure when every child fails, got %v", err)
+	}
+}
+
 func TestFetchSitemapHandlesNon200(t *testing.T) {
 	client := &fakeHTTPClient{
 		responses: map[string]fakeResponse{
@@ -158,7 +298,7 @@ func TestFetchSitemapHandlesNon200(t *testing.T) {
 		},
 	}
 
-	_, err := fetchSitemap(context.Background(), client, "https://example.com/root.xml", "p1", nil)
+	_, err := streamSitemapDocument[googleNewsURL](context.Background(), client, "https://example.com/root.xml", Provider{ID: "p1"}, nil, 0)
 	if err == nil || !strings.Contains(err.Error(), "status 400") {
 		t.Fatalf("expected status error, got %v", err)
 	}
@@ -174,8 +314,7 @@ func TestParseHelpers(t *testing.T) {
 		t.Errorf("expected nil keywords on blank input")
 	}
 
-	tm := parsePublicationDate("not-a-date")
-	if !tm.IsZero() {
+	if tm, ok := ParseDate("not-a-date", time.UTC); ok || !tm.IsZero() {
 		t.Errorf("expected zero time on invalid input, got %v", tm)
 	}
 
@@ -0,0 +1,186 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/samvad-hq/samvad-news-harvester/internal/domain"
+)
+
+// defaultSourceDateLayout renders a bare {date} placeholder.
+const defaultSourceDateLayout = "2006-01-02"
+
+// sourceDatePlaceholder matches {date...} placeholders; the part after "date" is validated by renderSourceURL.
+var sourceDatePlaceholder = regexp.MustCompile(`\{date[^}]*\}`)
+
+// sourceDateSpec parses the remainder of a placeholder: an optional day offset and an optional Go layout,
+// e.g. "", "-1", ":2006/01/02" or "+1:20060102".
+var sourceDateSpec = regexp.MustCompile(`^([+-]\d{1,3})?(?::(.+))?$`)
+
+// Sources returns the provider's source URLs for a run at now: source_url followed by source_urls, with
+// {date} placeholders rendered in the provider's timezone and duplicates removed.
+func (p Provider) Sources(now time.Time) ([]string, error) {
+	now = now.In(ProviderLocation(p))
+
+	raw := append([]string{p.SourceURL}, p.SourceURLs...)
+	seen := make(map[string]struct{}, len(raw))
+	out := make([]string, 0, len(raw))
+	for _, tmpl := range raw {
+		tmpl = strings.TrimSpace(tmpl)
+		if tmpl == "" {
+			continue
+		}
+		rendered, err := renderSourceURL(tmpl, now)
+		if err != nil {
+			return nil, err
+		}
+		if _, dup := seen[rendered]; dup {
+			continue
+		}
+		seen[rendered] = struct{}{}
+		out = append(out, rendered)
+	}
+	return out, nil
+}
+
+// hasMultipleSources reports whether the provider needs expanding before a fetcher sees it.
+func (p Provider) hasMultipleSources() bool {
+	return len(p.SourceURLs) > 0 || sourceDatePlaceholder.MatchString(p.SourceURL)
+}
+
+// renderSourceURL replaces {date}, {date-1}, {date:LAYOUT} and {date+N:LAYOUT} placeholders in tmpl.
+// The offset is in days relative to now; LAYOUT is a Go time layout and defaults to 2006-01-02.
+func renderSourceURL(tmpl string, now time.Time) (string, error) {
+	var renderErr error
+	out := sourceDatePlaceholder.ReplaceAllStringFunc(tmpl, func(placeholder string) string {
+		spec := strings.TrimSuffix(strings.TrimPrefix(placeholder, "{date"), "}")
+		m := sourceDateSpec.FindStringSubmatch(spec)
+		if m == nil {
+			renderErr = fmt.Errorf("invalid source url placeholder %s in %q", placeholder, tmpl)
+			return placeholder
+		}
+
+		day := now
+		if m[1] != "" {
+			offset, _ := strconv.Atoi(m[1])
+			day = now.AddDate(0, 0, offset)
+		}
+		layout := defaultSourceDateLayout
+		if m[2] != "" {
+			layout = m[2]
+		}
+		return day.Format(layout)
+	})
+	if renderErr != nil {
+		return "", renderErr
+	}
+	return out, nil
+}
+
+// multiSourceFetcher fetches every source of a provider with the wrapped fetcher and merges the results.
+type multiSourceFetcher struct {
+	inner Fetcher
+	now   func() time.Time
+	fetchStats
+}
+
+// newMultiSourceFetcher wraps inner so providers with source_urls or dated source_url templates are expanded.
+func newMultiSourceFetcher(inner Fetcher) *multiSourceFetcher {
+	return &multiSourceFetcher{inner: inner, now: time.Now}
+}
+
+// ID returns the wrapped fetcher's id.
+func (f *multiSourceFetcher) ID() string {
+	return f.inner.ID()
+}
+
+// Fetch renders the provider's sources, fetches each with a single-source copy of cfg and returns the
+// articles de-duplicated by ID in source order. Failed sources are reported through a *PartialError
+// when at least one source succeeded.
+func (f *multiSourceFetcher) Fetch(ctx context.Context, cfg Provider) ([]domain.Article, error) {
+	sources, err := cfg.Sources(f.now())
+	if err != nil {
+		return nil, fmt.Errorf("provider %q: %w", cfg.ID, err)
+	}
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("provider %q source_url is empty", cfg.ID)
+	}
+
+	var (
+		articles   []domain.Article
+		failures   []SourceFailure
+		succeeded  int
+		stats      map[string]int
+		validators map[string]domain.SourceValidators
+	)
+	seen := make(map[string]struct{})
+	for _, source := range sources {
+		single := cfg
+		single.SourceURL = source
+		single.SourceURLs = nil
+
+		found, err := f.inner.Fetch(ctx, single)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		if partial, ok := IsPartial(err); ok {
+			failures = append(failures, partial.Failures...)
+		} else if err != nil {
+			failures = append(failures, SourceFailure{URL: source, Err: err})
+			continue
+		}
+		succeeded++
+
+		if holder, ok := f.inner.(validatorHolder); ok {
+			for url, v := range holder.takePending(cfg.ID) {
+				if validators == nil {
+					validators = make(map[string]domain.SourceValidators)
+				}
+				validators[url] = v
+			}
+		}
+		if reporter, ok := f.inner.(FetchStatsReporter); ok {
+			for k, v := range reporter.LastFetchStats(cfg.ID) {
+				if stats == nil {
+					stats = make(map[string]int)
+				}
+				stats[k] += v
+			}
+		}
+		for _, art := range found {
+			if _, dup := seen[art.ID]; dup {
+				continue
+			}
+			seen[art.ID] = struct{}{}
+			articles = append(articles, art)
+		}
+	}
+	f.record(cfg.ID, stats)
+	if holder, ok := f.inner.(validatorHolder); ok {
+		holder.hold(cfg.ID, validators)
+	}
+
+	if len(failures) == 0 {
+		return articles, nil
+	}
+	if succeeded > 0 {
+		return articles, &PartialError{Failures: failures}
+	}
+	errs := make([]error, 0, len(failures))
+	for _, failure := range failures {
+		errs = append(errs, fmt.Errorf("%s: %w", failure.URL, failure.Err))
+	}
+	return nil, fmt.Errorf("%s every source failed: %w", cfg.ID, errors.Join(errs...))
+}
+
+// CommitValidators commits the validators the wrapped fetcher holds for every source of the provider.
+func (f *multiSourceFetcher) CommitValidators(providerID string) {
+	if committer, ok := f.inner.(ValidatorCommitter); ok {
+		committer.CommitValidators(providerID)
+	}
+}
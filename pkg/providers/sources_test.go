@@ -0,0 +1,135 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/samvad-hq/samvad-news-harvester/internal/domain"
+)
+
+func TestProviderSourcesRendersDatesInTimezone(t *testing.T) {
+	// 20:00 UTC on the 14th is already 01:30 on the 15th in India.
+	now := time.Date(2026, 10, 14, 20, 0, 0, 0, time.UTC)
+	cfg := Provider{
+		SourceURL: "https://example.com/sitemap/{date}.xml",
+		SourceURLs: []string{
+			"https://example.com/sitemap/{date-1}.xml",
+			"https://example.com/archive/{date:2006/01/02}/index.xml",
+			"https://example.com/sitemap/{date+0}.xml",
+		},
+		Config: map[string]any{ConfigTimezoneKey: "Asia/Kolkata"},
+	}
+
+	got, err := cfg.Sources(now)
+	if err != nil {
+		t.Fatalf("Sources: %v", err)
+	}
+	want := []string{
+		"https://example.com/sitemap/2026-10-15.xml",
+		"https://example.com/sitemap/2026-10-14.xml",
+		"https://example.com/archive/2026/10/15/index.xml",
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	if _, err := (Provider{SourceURL: "https://example.com/{date~1}.xml"}).Sources(now); err == nil {
+		t.Fatalf("expected error for malformed placeholder")
+	}
+}
+
+// sourceFetcher serves preset results keyed by source URL.
+type sourceFetcher struct {
+	results map[string][]domain.Article
+	errs    map[string]error
+	calls   []string
+	fetchStats
+}
+
+func (f *sourceFetcher) ID() string { return "sources" }
+
+func (f *sourceFetcher) Fetch(_ context.Context, cfg Provider) ([]domain.Article, error) {
+	f.calls = append(f.calls, cfg.SourceURL)
+	f.record(cfg.ID, map[string]int{FetchStatDateParseFailures: 1})
+	return f.results[cfg.SourceURL], f.errs[cfg.SourceURL]
+}
+
+func TestMultiSourceFetcherMergesAndDedupes(t *testing.T) {
+	inner := &sourceFetcher{
+		results: map[string][]domain.Article{
+			"https://example.com/india.xml": {{ID: "a"}, {ID: "b"}},
+			"https://example.com/world.xml": {{ID: "b"}, {ID: "c"}},
+		},
+		errs: map[string]error{"https://example.com/sports.xml": errors.New("status 404")},
+	}
+	reg := NewTypeFetcherRegistry(map[string]Fetcher{"multi": inner})
+	cfg := Provider{
+		ID:         "p1",
+		Type:       "multi",
+		SourceURL:  "https://example.com/india.xml",
+		SourceURLs: []string{"https://example.com/world.xml", "https://example.com/sports.xml"},
+	}
+
+	fetcher, err := reg.FetcherFor(cfg)
+	if err != nil {
+		t.Fatalf("FetcherFor: %v", err)
+	}
+	articles, err := fetcher.Fetch(context.Background(), cfg)
+	partial, ok := IsPartial(err)
+	if !ok || !slices.Equal(partial.URLs(), []string{"https://example.com/sports.xml"}) {
+		t.Fatalf("expected partial error for sports source, got %v", err)
+	}
+
+	var ids []string
+	for _, a := range articles {
+		ids = append(ids, a.ID)
+	}
+	if !slices.Equal(ids, []string{"a", "b", "c"}) {
+		t.Fatalf("got ids %v", ids)
+	}
+	if stats := fetcher.(FetchStatsReporter).LastFetchStats("p1"); stats[FetchStatDateParseFailures] != 2 {
+		t.Fatalf("expected stats summed over successful sources, got %v", stats)
+	}
+}
+
+func TestMultiSourceFetcherFailsWhenEverySourceFails(t *testing.T) {
+	inner := &sourceFetcher{errs: map[string]error{
+		"https://example.com/a.xml": errors.New("boom"),
+		"https://example.com/b.xml": errors.New("boom"),
+	}}
+	fetcher := newMultiSourceFetcher(inner)
+	_, err := fetcher.Fetch(context.Background(), Provider{
+		ID:         "p1",
+		SourceURLs: []string{"https://example.com/a.xml", "https://example.com/b.xml"},
+	})
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+	if _, partial := IsPartial(err); partial {
+		t.Fatalf("expected a hard failure, got partial %v", err)
+	}
+}
+
+func TestRegistryLeavesSingleStaticSourceUnwrapped(t *testing.T) {
+	client := &fakeHTTPClient{responses: map[string]fakeResponse{}}
+	reg := DefaultFetcherRegistry(client, nil)
+
+	fetcher, err := reg.FetcherFor(Provider{ID: "p1", Type: ProviderTypeRSS, SourceURL: "https://example.com/feed.xml"})
+	if err != nil {
+		t.Fatalf("FetcherFor: %v", err)
+	}
+	if _, wrapped := fetcher.(*multiSourceFetcher); wrapped {
+		t.Fatalf("single static source should not be wrapped")
+	}
+
+	fetcher, err = reg.FetcherFor(Provider{ID: "p1", Type: ProviderTypeRSS, SourceURL: "https://example.com/{date}.xml"})
+	if err != nil {
+		t.Fatalf("FetcherFor: %v", err)
+	}
+	if _, wrapped := fetcher.(*multiSourceFetcher); !wrapped || fetcher.ID() != ProviderTypeRSS {
+		t.Fatalf("templated source should be wrapped, got %T", fetcher)
+	}
+}
@@ -0,0 +1,29 @@
+package providers
+
+import (
+	"maps"
+	"sync"
+)
+
+// fetchStats stores the counters of the most recent run per provider; the zero value is ready to use.
+type fetchStats struct {
+	mu   sync.Mutex
+	last map[string]map[string]int
+}
+
+// record replaces the counters kept for providerID.
+func (s *fetchStats) record(providerID string, stats map[string]int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.last == nil {
+		s.last = make(map[string]map[string]int)
+	}
+	s.last[providerID] = stats
+}
+
+// LastFetchStats returns a copy of the counters from the provider's most recent run.
+func (s *fetchStats) LastFetchStats(providerID string) map[string]int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return maps.Clone(s.last[providerID])
+}
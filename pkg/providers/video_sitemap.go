@@ -0,0 +1,66 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/samvad-hq/samvad-news-harvester/internal/domain"
+)
+
+// videoSitemapFetcher implements Fetcher for video sitemap providers.
+type videoSitemapFetcher struct {
+	client HTTPClient
+	validatorCache
+	fetchStats
+}
+
+// NewVideoSitemapFetcher builds a Fetcher for video sitemap providers.
+// validators is optional; when set, unchanged leaf sitemaps are skipped via conditional GETs.
+func NewVideoSitemapFetcher(client HTTPClient, validators ValidatorStore) Fetcher {
+	if client == nil {
+		client = DefaultHTTPClient()
+	}
+	return &videoSitemapFetcher{client: client, validatorCache: validatorCache{store: validators}}
+}
+
+// ID returns the provider type for the video sitemap fetcher.
+func (f *videoSitemapFetcher) ID() string {
+	return ProviderTypeVideoSitemap
+}
+
+// Fetch retrieves video articles from a video sitemap provider, following sitemap indexes if necessary.
+func (f *videoSitemapFetcher) Fetch(ctx context.Context, cfg Provider) ([]domain.Article, error) {
+	if !strings.EqualFold(cfg.Type, ProviderTypeVideoSitemap) {
+		return nil, fmt.Errorf("video sitemap fetcher received incompatible provider type %q", cfg.Type)
+	}
+	if strings.TrimSpace(cfg.SourceURL) == "" {
+		return nil, fmt.Errorf("provider %q source_url is empty", cfg.ID)
+	}
+
+	walker := newSitemapWalker[videoSitemapURL](f.client, f.store, cfg, Headers(cfg))
+	urls, err := walker.walk(ctx, cfg.SourceURL)
+	if err != nil {
+		return nil, err
+	}
+	f.hold(cfg.ID, walker.validators())
+
+	// A *PartialError means some nested sitemaps failed; whatever the others produced is still returned.
+	err = walker.failureErr()
+	if _, partial := IsPartial(err); err != nil && !partial {
+		return nil, err
+	}
+	if len(urls) == 0 && walker.unchanged > 0 {
+		return nil, err
+	}
+
+	dates := newDateParser(cfg)
+	articles := buildArticlesFromVideoSitemap(cfg.ID, urls, dates)
+	stats := walker.limitStats()
+	stats[FetchStatDateParseFailures] = dates.failures
+	f.record(cfg.ID, stats)
+	if len(articles) == 0 {
+		return nil, fmt.Errorf("%s video sitemap returned no records", cfg.ID)
+	}
+	return articles, err
+}
@@ -0,0 +1,103 @@
+package providers
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/samvad-hq/samvad-news-harvester/internal/domain"
+)
+
+func TestVideoSitemapFetcherBuildsVideoArticles(t *testing.T) {
+	leaf := []byte(`<?xml version="1.0"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9"
+        xmlns:video="http://www.google.com/schemas/sitemap-video/1.1"
+        xmlns:news="http://www.google.com/schemas/sitemap-news/0.9">
+  <url>
+    <loc>https://example.com/videos/budget</loc>
+    <video:video>
+      <video:thumbnail_loc>https://example.com/thumbs/budget.jpg</video:thumbnail_loc>
+      <video:title> Budget explained </video:title>
+      <video:description>What changes for you.</video:description>
+      <video:content_loc>https://cdn.example.com/budget.mp4</video:content_loc>
+      <video:player_loc>https://example.com/player?id=1</video:player_loc>
+      <video:duration>185</video:duration>
+      <video:publication_date>2024-02-01T10:00:00+05:30</video:publication_date>
+      <video:tag>budget</video:tag>
+      <video:tag>economy</video:tag>
+      <video:live>no</video:live>
+    </video:video>
+    <video:video>
+      <video:title>Second clip</video:title>
+      <video:live>yes</video:live>
+    </video:video>
+  </url>
+  <url>
+    <loc>https://example.com/videos/live</loc>
+    <video:video>
+      <video:thumbnail_loc>https://example.com/thumbs/live.jpg</video:thumbnail_loc>
+    </video:video>
+    <news:news>
+      <news:publication><news:name>Example</news:name><news:language>en</news:language></news:publication>
+      <news:publication_date>2024-02-02</news:publication_date>
+      <news:title>Live coverage</news:title>
+    </news:news>
+  </url>
+  <url><loc>https://example.com/page-without-video</loc></url>
+</urlset>`)
+
+	client := &fakeHTTPClient{responses: map[string]fakeResponse{
+		"https://example.com/video.xml": {body: leaf, statusCode: http.StatusOK},
+	}}
+	articles, err := NewVideoSitemapFetcher(client, nil).Fetch(context.Background(), Provider{
+		ID:        "p1",
+		Type:      ProviderTypeVideoSitemap,
+		SourceURL: "https://example.com/video.xml",
+	})
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if len(articles) != 2 {
+		t.Fatalf("expected 2 video articles, got %d: %#v", len(articles), articles)
+	}
+
+	first := articles[0]
+	if first.MediaType != domain.MediaTypeVideo || first.Title != "Budget explained" || first.Description != "What changes for you." {
+		t.Fatalf("unexpected first article %#v", first)
+	}
+	if first.ImageURL != "https://example.com/thumbs/budget.jpg" || !slices.Equal(first.Keywords, []string{"budget", "economy"}) {
+		t.Fatalf("unexpected thumbnail or keywords %#v", first)
+	}
+	if want := time.Date(2024, 2, 1, 4, 30, 0, 0, time.UTC); !first.PublishedAt.Equal(want) {
+		t.Fatalf("expected published %v, got %v", want, first.PublishedAt)
+	}
+	if len(first.Videos) != 2 {
+		t.Fatalf("expected 2 videos, got %#v", first.Videos)
+	}
+	video := first.Videos[0]
+	if video.ContentURL != "https://cdn.example.com/budget.mp4" || video.PlayerURL != "https://example.com/player?id=1" ||
+		video.DurationSeconds != 185 || video.Live || !first.Videos[1].Live {
+		t.Fatalf("unexpected video metadata %#v", first.Videos)
+	}
+
+	second := articles[1]
+	if second.Title != "Live coverage" || second.PublicationName != "Example" || second.Language != "en" {
+		t.Fatalf("expected news metadata fallback, got %#v", second)
+	}
+	if second.PublishedAt.IsZero() {
+		t.Fatalf("expected news publication date fallback")
+	}
+}
+
+func TestVideoSitemapFetcherRejectsWrongType(t *testing.T) {
+	_, err := NewVideoSitemapFetcher(&fakeHTTPClient{}, nil).Fetch(context.Background(), Provider{
+		ID:        "p1",
+		Type:      ProviderTypeGoogleNews,
+		SourceURL: "https://example.com/video.xml",
+	})
+	if err == nil {
+		t.Fatalf("expected type mismatch error")
+	}
+}
@@ -0,0 +1,240 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"maps"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/samvad-hq/samvad-news-harvester/internal/domain"
+	"golang.org/x/net/publicsuffix"
+)
+
+// linkHeaderPart matches one `<url>; params` entry of an HTTP Link header.
+var linkHeaderPart = regexp.MustCompile(`<([^>]*)>\s*((?:;[^,<]*)*)`)
+
+// linkRelParam extracts the rel parameter of a Link header entry.
+var linkRelParam = regexp.MustCompile(`(?i);\s*rel\s*=\s*"?([^";]+)"?`)
+
+// ConfigPushHostsKey lists, comma-separated, the domains pushed entries may link to (subdomains included).
+// When unset, the subscriber fills it with the sites the polled feed links to.
+const ConfigPushHostsKey = "push_hosts"
+
+// SupportsPush reports whether the provider reads a single RSS or Atom feed that a WebSub hub can push.
+// google_news_rss feeds are excluded: their items link to other publishers, so pushes cannot be checked
+// against the provider's domain.
+func SupportsPush(cfg Provider) bool {
+	switch cfg.Type {
+	case ProviderTypeRSS, ProviderTypeAtom:
+		return !cfg.hasMultipleSources() && strings.TrimSpace(cfg.SourceURL) != ""
+	default:
+		return false
+	}
+}
+
+// FeedHub is what DiscoverHub learns from a provider's feed.
+type FeedHub struct {
+	// Hub is the advertised WebSub hub; empty when the feed names none.
+	Hub string
+	// Topic is the feed's rel="self" URL, or source_url when it has none.
+	Topic string
+	// LinkSites are the registrable domains the feed's entries link to.
+	LinkSites []string
+}
+
+// DiscoverHub fetches the provider's feed and returns the WebSub hub it advertises, the topic to subscribe
+// to and the sites its entries link to.
+func DiscoverHub(ctx context.Context, client HTTPClient, cfg Provider) (FeedHub, error) {
+	target, headers := Authorize(cfg, cfg.SourceURL, Headers(cfg))
+	resp, err := client.Get(ctx, target, headers)
+	if err != nil {
+		return FeedHub{}, fmt.Errorf("fetch %s feed: %w", cfg.ID, RedactError(cfg, err))
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return FeedHub{}, fmt.Errorf("%s feed returned status %d body: %s", cfg.ID, resp.StatusCode(), responseSnippet(resp.Body()))
+	}
+	body := resp.Body()
+	if bytes.HasPrefix(body, gzipMagic) {
+		if body, err = gunzip(body); err != nil {
+			return FeedHub{}, fmt.Errorf("decode %s feed: %w", cfg.ID, err)
+		}
+	}
+
+	hub, self := FeedHubLinks(resp.Header(), body)
+	out := FeedHub{Hub: hub, Topic: firstNonBlank(self, cfg.SourceURL)}
+	// A feed that does not parse simply contributes no sites.
+	articles, _ := parseFeedArticles(cfg, body, newDateParser(cfg))
+	seen := make(map[string]struct{})
+	for _, art := range articles {
+		site := siteDomain(art.URL)
+		if _, dup := seen[site]; dup || site == "" {
+			continue
+		}
+		seen[site] = struct{}{}
+		out.LinkSites = append(out.LinkSites, site)
+	}
+	return out, nil
+}
+
+// PushProvider returns cfg with push_hosts set to the sites its polled feed links to, plus the site of
+// source_url, unless push_hosts is already configured.
+func PushProvider(cfg Provider, linkSites []string) Provider {
+	if ConfigString(cfg, ConfigPushHostsKey, "") != "" {
+		return cfg
+	}
+	hosts := linkSites
+	if site := siteDomain(cfg.SourceURL); site != "" && !slices.Contains(hosts, site) {
+		hosts = append(slices.Clone(hosts), site)
+	}
+	if len(hosts) == 0 {
+		return cfg
+	}
+
+	out := cfg
+	out.Config = maps.Clone(cfg.Config)
+	if out.Config == nil {
+		out.Config = make(map[string]any, 1)
+	}
+	out.Config[ConfigPushHostsKey] = strings.Join(hosts, ",")
+	return out
+}
+
+// FeedHubLinks returns the rel="hub" and rel="self" URLs from the Link header or, failing that, from the
+// <link>/<atom:link> elements of an RSS or Atom document.
+func FeedHubLinks(header http.Header, body []byte) (hub, self string) {
+	for _, value := range header.Values("Link") {
+		for _, m := range linkHeaderPart.FindAllStringSubmatch(value, -1) {
+			rel := linkRelParam.FindStringSubmatch(m[2])
+			if rel == nil {
+				continue
+			}
+			for _, r := range strings.Fields(strings.ToLower(rel[1])) {
+				switch {
+				case r == "hub" && hub == "":
+					hub = strings.TrimSpace(m[1])
+				case r == "self" && self == "":
+					self = strings.TrimSpace(m[1])
+				}
+			}
+		}
+	}
+
+	dec := xml.NewDecoder(bytes.NewReader(body))
+	dec.Strict = false
+	for hub == "" || self == "" {
+		tok, err := dec.Token()
+		if err != nil {
+			break
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok || start.Name.Local != "link" {
+			continue
+		}
+		var rel, href string
+		for _, attr := range start.Attr {
+			switch attr.Name.Local {
+			case "rel":
+				rel = strings.ToLower(strings.TrimSpace(attr.Value))
+			case "href":
+				href = strings.TrimSpace(attr.Value)
+			}
+		}
+		switch {
+		case href == "":
+		case rel == "hub" && hub == "":
+			hub = href
+		case rel == "self" && self == "":
+			self = href
+		}
+	}
+	return hub, self
+}
+
+// ParsePushedFeed builds articles from feed content delivered by a WebSub hub, using the provider's type
+// to pick the parser exactly as its fetcher would. Entries linking outside the provider's push_hosts (the
+// site of source_url when unset) are dropped and counted in offSite, so a push cannot publish other
+// sites' links as the provider's.
+func ParsePushedFeed(cfg Provider, body []byte) (articles []domain.Article, offSite int, err error) {
+	if !SupportsPush(cfg) {
+		return nil, 0, fmt.Errorf("provider %q of type %q does not accept pushed feeds", cfg.ID, cfg.Type)
+	}
+	if bytes.HasPrefix(body, gzipMagic) {
+		if body, err = gunzip(body); err != nil {
+			return nil, 0, fmt.Errorf("decode %s push: %w", cfg.ID, err)
+		}
+	}
+	articles, err = parseFeedArticles(cfg, body, newDateParser(cfg))
+	if err != nil {
+		return nil, 0, fmt.Errorf("decode %s push: %w", cfg.ID, err)
+	}
+
+	allowed := pushHosts(cfg)
+	kept := articles[:0]
+	for _, art := range articles {
+		if !onHosts(urlHost(art.URL), allowed) {
+			offSite++
+			continue
+		}
+		kept = append(kept, art)
+	}
+	return kept, offSite, nil
+}
+
+// parseFeedArticles parses an RSS or Atom document according to the provider's type.
+func parseFeedArticles(cfg Provider, body []byte, dates *dateParser) ([]domain.Article, error) {
+	if cfg.Type == ProviderTypeAtom {
+		entries, err := parseAtomFeed(body)
+		if err != nil {
+			return nil, fmt.Errorf("decode atom: %w", err)
+		}
+		return buildArticlesFromAtom(cfg.ID, entries, dates), nil
+	}
+	items, err := parseRSSFeed(body)
+	if err != nil {
+		return nil, fmt.Errorf("decode rss: %w", err)
+	}
+	return buildArticlesFromRSS(cfg.ID, items, dates), nil
+}
+
+// pushHosts returns the lowercase domains pushed entries may link to.
+func pushHosts(cfg Provider) []string {
+	if raw := ConfigString(cfg, ConfigPushHostsKey, ""); raw != "" {
+		return trimmedValues(strings.Split(strings.ToLower(raw), ","))
+	}
+	if site := siteDomain(cfg.SourceURL); site != "" {
+		return []string{site}
+	}
+	return nil
+}
+
+// onHosts reports whether host is one of domains or a subdomain of one.
+func onHosts(host string, domains []string) bool {
+	if host == "" {
+		return false
+	}
+	for _, d := range domains {
+		if host == d || strings.HasSuffix(host, "."+d) {
+			return true
+		}
+	}
+	return false
+}
+
+// siteDomain returns the registrable domain (eTLD+1) of raw's host, e.g. example.co.uk for
+// feeds.example.co.uk, or "" when raw has no such domain.
+func siteDomain(raw string) string {
+	host := urlHost(raw)
+	if host == "" {
+		return ""
+	}
+	site, err := publicsuffix.EffectiveTLDPlusOne(host)
+	if err != nil {
+		return ""
+	}
+	return site
+}
@@ -0,0 +1,162 @@
+package providers
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+)
+
+func TestFeedHubLinks(t *testing.T) {
+	rss := []byte(`<rss xmlns:atom="http://www.w3.org/2005/Atom"><channel>
+  <atom:link rel="self" href="https://example.com/feed.xml"/>
+  <atom:link rel="hub" href="https://hub.example/"/>
+  <item><link>https://example.com/a</link></item>
+</channel></rss>`)
+	hub, self := FeedHubLinks(nil, rss)
+	if hub != "https://hub.example/" || self != "https://example.com/feed.xml" {
+		t.Fatalf("unexpected rss links: hub=%q self=%q", hub, self)
+	}
+
+	header := http.Header{}
+	header.Add("Link", `<https://push.example/hub>; rel="hub", <https://example.com/canonical.xml>; rel=self`)
+	hub, self = FeedHubLinks(header, []byte(`<feed xmlns="http://www.w3.org/2005/Atom"><link rel="hub" href="https://ignored.example/"/></feed>`))
+	if hub != "https://push.example/hub" || self != "https://example.com/canonical.xml" {
+		t.Fatalf("expected Link header to win, got hub=%q self=%q", hub, self)
+	}
+
+	if hub, _ := FeedHubLinks(nil, []byte(`<rss><channel><link>https://example.com</link></channel></rss>`)); hub != "" {
+		t.Fatalf("expected no hub, got %q", hub)
+	}
+}
+
+func TestDiscoverHubFallsBackToSourceURL(t *testing.T) {
+	feed := []byte(`<feed xmlns="http://www.w3.org/2005/Atom"><link rel="hub" href="https://hub.example/"/></feed>`)
+	client := &fakeHTTPClient{responses: map[string]fakeResponse{
+		"https://example.com/atom.xml": {body: feed, statusCode: http.StatusOK},
+	}}
+	got, err := DiscoverHub(context.Background(), client, Provider{ID: "p", Type: ProviderTypeAtom, SourceURL: "https://example.com/atom.xml"})
+	if err != nil {
+		t.Fatalf("DiscoverHub: %v", err)
+	}
+	if got.Hub != "https://hub.example/" || got.Topic != "https://example.com/atom.xml" {
+		t.Fatalf("unexpected hub/topic: %q %q", got.Hub, got.Topic)
+	}
+}
+
+func TestParsePushedFeed(t *testing.T) {
+	atom := []byte(`<feed xmlns="http://www.w3.org/2005/Atom">
+  <entry><title>Pushed</title><link href="https://example.com/pushed"/><updated>2024-03-01T10:00:00Z</updated></entry>
+</feed>`)
+	cfg := Provider{ID: "p", Type: ProviderTypeAtom, SourceURL: "https://blog.example.com/atom.xml"}
+	articles, offSite, err := ParsePushedFeed(cfg, atom)
+	if err != nil {
+		t.Fatalf("ParsePushedFeed: %v", err)
+	}
+	if len(articles) != 1 || articles[0].URL != "https://example.com/pushed" || articles[0].PublishedAt.IsZero() || offSite != 0 {
+		t.Fatalf("unexpected articles: %+v (off site %d)", articles, offSite)
+	}
+
+	if _, _, err := ParsePushedFeed(Provider{ID: "p", Type: ProviderTypeSitemap, SourceURL: "https://example.com/sitemap.xml"}, atom); err == nil {
+		t.Fatalf("expected error for a provider type without push support")
+	}
+}
+
+func TestSupportsPush(t *testing.T) {
+	tests := []struct {
+		cfg  Provider
+		want bool
+	}{
+		{Provider{Type: ProviderTypeRSS, SourceURL: "https://example.com/rss"}, true},
+		{Provider{Type: ProviderTypeAtom, SourceURL: "https://example.com/atom"}, true},
+		{Provider{Type: ProviderTypeRSS, SourceURL: "https://example.com/{date}/rss"}, false},
+		{Provider{Type: ProviderTypeSitemap, SourceURL: "https://example.com/sitemap.xml"}, false},
+		{Provider{Type: ProviderTypeGoogleNewsRSS, SourceURL: "https://news.google.com/rss/search?q=x"}, false},
+	}
+	for _, tt := range tests {
+		if got := SupportsPush(tt.cfg); got != tt.want {
+			t.Errorf("SupportsPush(%s %s) = %v, want %v", tt.cfg.Type, tt.cfg.SourceURL, got, tt.want)
+		}
+	}
+}
+
+func TestParsePushedFeedDropsOffSiteLinks(t *testing.T) {
+	rss := []byte(`<rss><channel>
+  <item><link>https://www.example.co.uk/news/1</link></item>
+  <item><link>https://other.co.uk/news/2</link></item>
+  <item><link>https://example.co.uk.evil.example/news/3</link></item>
+  <item><link>/relative</link></item>
+</channel></rss>`)
+	cfg := Provider{ID: "p", Type: ProviderTypeRSS, SourceURL: "https://feeds.example.co.uk/rss"}
+	articles, offSite, err := ParsePushedFeed(cfg, rss)
+	if err != nil {
+		t.Fatalf("ParsePushedFeed: %v", err)
+	}
+	if len(articles) != 1 || articles[0].URL != "https://www.example.co.uk/news/1" {
+		t.Fatalf("expected only the on-site entry, got %+v", articles)
+	}
+	if offSite != 3 {
+		t.Fatalf("expected 3 off-site entries, got %d", offSite)
+	}
+}
+
+func TestParsePushedFeedAllowsSitesThePolledFeedLinksTo(t *testing.T) {
+	polled := []byte(`<rss><channel>
+  <atom:link xmlns:atom="http://www.w3.org/2005/Atom" rel="hub" href="https://hub.example/"/>
+  <item><link>https://www.bbc.co.uk/news/1</link></item>
+  <item><link>https://www.bbc.com/news/2</link></item>
+</channel></rss>`)
+	cfg := Provider{ID: "bbc", Type: ProviderTypeRSS, SourceURL: "https://feeds.bbci.co.uk/news/rss.xml"}
+	client := &fakeHTTPClient{responses: map[string]fakeResponse{
+		cfg.SourceURL: {body: polled, statusCode: http.StatusOK},
+	}}
+	feed, err := DiscoverHub(context.Background(), client, cfg)
+	if err != nil {
+		t.Fatalf("DiscoverHub: %v", err)
+	}
+	if want := []string{"bbc.co.uk", "bbc.com"}; !slices.Equal(feed.LinkSites, want) {
+		t.Fatalf("expected link sites %v, got %v", want, feed.LinkSites)
+	}
+
+	pushed := []byte(`<rss><channel>
+  <item><link>https://www.bbc.co.uk/news/3</link></item>
+  <item><link>https://www.bbc.com/news/4</link></item>
+  <item><link>https://feeds.bbci.co.uk/news/5</link></item>
+  <item><link>https://evil.example/news/6</link></item>
+</channel></rss>`)
+	push := PushProvider(cfg, feed.LinkSites)
+	articles, offSite, err := ParsePushedFeed(push, pushed)
+	if err != nil {
+		t.Fatalf("ParsePushedFeed: %v", err)
+	}
+	if len(articles) != 3 || offSite != 1 {
+		t.Fatalf("expected 3 kept and 1 off-site, got %+v (off site %d)", articles, offSite)
+	}
+	if cfg.Config != nil {
+		t.Fatalf("PushProvider modified the original config: %v", cfg.Config)
+	}
+
+	// Without the polled sites only source_url's own site is trusted.
+	if _, offSite, _ := ParsePushedFeed(cfg, pushed); offSite != 3 {
+		t.Fatalf("expected 3 off-site entries without push_hosts, got %d", offSite)
+	}
+}
+
+func TestParsePushedFeedHonoursConfiguredPushHosts(t *testing.T) {
+	rss := []byte(`<rss><channel>
+  <item><link>https://news.example.org/1</link></item>
+  <item><link>https://www.example.com/2</link></item>
+</channel></rss>`)
+	cfg := Provider{ID: "p", Type: ProviderTypeRSS, SourceURL: "https://cdn.feedhost.net/p.xml",
+		Config: map[string]any{ConfigPushHostsKey: " Example.org "}}
+	if got := PushProvider(cfg, []string{"example.com"}); got.Config[ConfigPushHostsKey] != " Example.org " {
+		t.Fatalf("PushProvider overrode the configured hosts: %v", got.Config)
+	}
+	articles, offSite, err := ParsePushedFeed(cfg, rss)
+	if err != nil {
+		t.Fatalf("ParsePushedFeed: %v", err)
+	}
+	if len(articles) != 1 || articles[0].URL != "https://news.example.org/1" || offSite != 1 {
+		t.Fatalf("expected only the example.org entry, got %+v (off site %d)", articles, offSite)
+	}
+}
@@ -0,0 +1,104 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/samvad-hq/samvad-news-harvester/internal/domain"
+)
+
+const (
+	// ConfigLastmodWindowKey bounds how old a sitemap <lastmod> may be before the URL is skipped.
+	ConfigLastmodWindowKey = "lastmod_window"
+
+	defaultLastmodWindow = 48 * time.Hour
+)
+
+// xmlSitemapFetcher implements Fetcher for plain <urlset> sitemaps without the news namespace.
+type xmlSitemapFetcher struct {
+	client HTTPClient
+	now    func() time.Time
+	validatorCache
+	fetchStats
+}
+
+// NewSitemapFetcher builds a Fetcher for plain XML sitemap providers.
+func NewSitemapFetcher(client HTTPClient, validators ValidatorStore) Fetcher {
+	if client == nil {
+		client = DefaultHTTPClient()
+	}
+	return &xmlSitemapFetcher{client: client, validatorCache: validatorCache{store: validators}, now: time.Now}
+}
+
+// ID returns the provider type for the plain sitemap fetcher.
+func (f *xmlSitemapFetcher) ID() string {
+	return ProviderTypeSitemap
+}
+
+// Fetch retrieves recently modified URLs from a plain XML sitemap provider.
+// Titles are left empty for the scraper to fill in; PublishedAt carries the lastmod value.
+func (f *xmlSitemapFetcher) Fetch(ctx context.Context, cfg Provider) ([]domain.Article, error) {
+	if !strings.EqualFold(cfg.Type, ProviderTypeSitemap) {
+		return nil, fmt.Errorf("sitemap fetcher received incompatible provider type %q", cfg.Type)
+	}
+	if strings.TrimSpace(cfg.SourceURL) == "" {
+		return nil, fmt.Errorf("provider %q source_url is empty", cfg.ID)
+	}
+
+	walker := newSitemapWalker[sitemapURL](f.client, f.store, cfg, Headers(cfg))
+	entries, err := walker.walk(ctx, cfg.SourceURL)
+	if err != nil {
+		return nil, err
+	}
+	f.hold(cfg.ID, walker.validators())
+	partialErr := walker.failureErr()
+	if _, partial := IsPartial(partialErr); partialErr != nil && !partial {
+		return nil, partialErr
+	}
+	if len(entries) == 0 && walker.unchanged > 0 {
+		return nil, partialErr
+	}
+
+	window := ConfigDuration(cfg, ConfigLastmodWindowKey, defaultLastmodWindow)
+	dates := newDateParser(cfg)
+	articles := buildArticlesFromURLSet(cfg.ID, entries, f.now().Add(-window), dates)
+	stats := walker.limitStats()
+	stats[FetchStatDateParseFailures] = dates.failures
+	f.record(cfg.ID, stats)
+	if len(articles) == 0 {
+		return nil, fmt.Errorf("%s sitemap returned no records modified within %s", cfg.ID, window)
+	}
+	return articles, partialErr
+}
+
+type sitemapURL struct {
+	Loc        string `xml:"loc"`
+	LastMod    string `xml:"lastmod"`
+	ChangeFreq string `xml:"changefreq"`
+}
+
+// buildArticlesFromURLSet keeps entries whose lastmod is at or after since and converts them to articles.
+func buildArticlesFromURLSet(providerID string, entries []sitemapURL, since time.Time, dates *dateParser) []domain.Article {
+	articles := make([]domain.Article, 0, len(entries))
+	for _, entry := range entries {
+		loc := strings.TrimSpace(entry.Loc)
+		if loc == "" {
+			continue
+		}
+
+		lastMod := dates.parse(entry.LastMod)
+		if lastMod.IsZero() || lastMod.Before(since) {
+			continue
+		}
+
+		articles = append(articles, domain.Article{
+			ProviderID:  providerID,
+			ID:          hashURL(loc),
+			URL:         loc,
+			PublishedAt: lastMod,
+		})
+	}
+	return articles
+}
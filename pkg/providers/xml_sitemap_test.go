@@ -0,0 +1,80 @@
+package providers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestSitemapFetcherFiltersByLastmod(t *testing.T) {
+	indexXML := []byte(`
+<sitemapindex>
+  <sitemap><loc>https://example.com/pages.xml</loc></sitemap>
+</sitemapindex>`)
+	leafXML := []byte(`
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <url>
+    <loc>https://example.com/fresh</loc>
+    <lastmod>2024-03-10T08:00:00+05:30</lastmod>
+    <changefreq>hourly</changefreq>
+  </url>
+  <url>
+    <loc>https://example.com/date-only</loc>
+    <lastmod>2024-03-10</lastmod>
+  </url>
+  <url>
+    <loc>https://example.com/stale</loc>
+    <lastmod>2024-01-01</lastmod>
+  </url>
+  <url>
+    <loc>https://example.com/undated</loc>
+  </url>
+</urlset>`)
+
+	client := &fakeHTTPClient{
+		responses: map[string]fakeResponse{
+			"https://example.com/index.xml": {body: indexXML, statusCode: http.StatusOK},
+			"https://example.com/pages.xml": {body: leafXML, statusCode: http.StatusOK},
+		},
+	}
+
+	fetcher := &xmlSitemapFetcher{
+		client: client,
+		now:    func() time.Time { return time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC) },
+	}
+	articles, err := fetcher.Fetch(context.Background(), Provider{
+		ID:        "p1",
+		Type:      ProviderTypeSitemap,
+		SourceURL: "https://example.com/index.xml",
+		Config:    map[string]any{ConfigLastmodWindowKey: "36h"},
+	})
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if len(articles) != 2 {
+		t.Fatalf("expected 2 recent articles, got %d: %#v", len(articles), articles)
+	}
+	if articles[0].URL != "https://example.com/fresh" || articles[1].URL != "https://example.com/date-only" {
+		t.Fatalf("unexpected articles %#v", articles)
+	}
+	if articles[0].Title != "" {
+		t.Errorf("expected empty title for scraper to fill, got %q", articles[0].Title)
+	}
+	if !articles[0].PublishedAt.Equal(time.Date(2024, 3, 10, 2, 30, 0, 0, time.UTC)) {
+		t.Errorf("PublishedAt = %v", articles[0].PublishedAt)
+	}
+}
+
+func TestConfigDuration(t *testing.T) {
+	cfg := Provider{Config: map[string]any{"good": "90m", "bad": "soon"}}
+	if got := ConfigDuration(cfg, "good", time.Hour); got != 90*time.Minute {
+		t.Errorf("ConfigDuration good = %v", got)
+	}
+	if got := ConfigDuration(cfg, "bad", time.Hour); got != time.Hour {
+		t.Errorf("ConfigDuration bad = %v", got)
+	}
+	if got := ConfigDuration(cfg, "missing", time.Hour); got != time.Hour {
+		t.Errorf("ConfigDuration missing = %v", got)
+	}
+}
@@ -0,0 +1,37 @@
+package publishers
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/samvad-hq/samvad-news-harvester/internal/domain"
+)
+
+func TestEventJSONCarriesNewsMetadata(t *testing.T) {
+	evt := NewEvent("p1", "Provider", domain.Article{
+		ID:           "a1",
+		URL:          "https://example.com/a",
+		Language:     "hi",
+		Genres:       []string{"Blog"},
+		StockTickers: []string{"NSE:INFY"},
+		Access:       "Subscription",
+		Images:       []domain.Image{{URL: "https://example.com/a.jpg", Caption: "cap"}},
+		Alternates:   []domain.AlternateLink{{Language: "en", URL: "https://example.com/en/a"}},
+	})
+
+	data, err := json.Marshal(evt)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	for _, want := range []string{`"language":"hi"`, `"genres":["Blog"]`, `"stock_tickers":["NSE:INFY"]`, `"access":"Subscription"`, `"caption":"cap"`, `"alternates":[{"language":"en"`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("event JSON missing %s: %s", want, data)
+		}
+	}
+
+	bare, _ := json.Marshal(NewEvent("p1", "Provider", domain.Article{ID: "a2"}))
+	if strings.Contains(string(bare), `"genres"`) || strings.Contains(string(bare), `"images"`) {
+		t.Errorf("empty news metadata should be omitted: %s", bare)
+	}
+}
@@ -0,0 +1,131 @@
+package robots
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/samvad-hq/samvad-news-harvester/pkg/httpclient"
+)
+
+const (
+	// DefaultCacheTTL is how long a fetched robots.txt is reused.
+	DefaultCacheTTL = 24 * time.Hour
+	// unreachableTTL keeps failed lookups short-lived so transient outages recover quickly.
+	unreachableTTL = 10 * time.Minute
+)
+
+// Checker fetches and caches robots.txt per scheme+host.
+type Checker struct {
+	client httpclient.Client
+	ttl    time.Duration
+	now    func() time.Time
+
+	mu    sync.Mutex
+	hosts map[string]*hostEntry
+}
+
+type hostEntry struct {
+	mu      sync.Mutex
+	robots  *Robots
+	expires time.Time
+}
+
+// NewChecker builds a Checker using client to download robots.txt files.
+func NewChecker(client httpclient.Client, ttl time.Duration) *Checker {
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+	return &Checker{
+		client: client,
+		ttl:    ttl,
+		now:    time.Now,
+		hosts:  make(map[string]*hostEntry),
+	}
+}
+
+// Robots returns the (possibly cached) robots.txt for the host serving rawURL.
+// userAgent is sent when the file has to be downloaded.
+func (c *Checker) Robots(ctx context.Context, rawURL, userAgent string) (*Robots, error) {
+	root, err := siteRoot(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	entry, ok := c.hosts[root]
+	if !ok {
+		entry = &hostEntry{}
+		c.hosts[root] = entry
+	}
+	c.mu.Unlock()
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+
+	now := c.now()
+	if entry.robots != nil && now.Before(entry.expires) {
+		return entry.robots, nil
+	}
+
+	robots, ttl := c.fetch(ctx, root, userAgent)
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	entry.robots = robots
+	entry.expires = now.Add(ttl)
+	return robots, nil
+}
+
+// Allowed reports whether userAgent may fetch rawURL.
+func (c *Checker) Allowed(ctx context.Context, rawURL, userAgent string) (bool, error) {
+	robots, err := c.Robots(ctx, rawURL, userAgent)
+	if err != nil {
+		return false, err
+	}
+	return robots.Allowed(rawURL, userAgent), nil
+}
+
+// fetch downloads robots.txt following RFC 9309: 4xx means no restrictions, 5xx or
+// network failures mean the whole site is treated as disallowed until the next retry.
+func (c *Checker) fetch(ctx context.Context, root, userAgent string) (*Robots, time.Duration) {
+	headers := map[string]string{}
+	if userAgent != "" {
+		headers["User-Agent"] = userAgent
+	}
+
+	resp, err := c.client.Do(ctx, httpclient.Request{
+		Method:       http.MethodGet,
+		URL:          root + "/robots.txt",
+		Headers:      headers,
+		MaxBodyBytes: maxRobotsBytes,
+	})
+	if err != nil {
+		return DisallowAll(), unreachableTTL
+	}
+
+	switch code := resp.StatusCode(); {
+	case code >= 200 && code < 300:
+		return Parse(resp.Body()), c.ttl
+	case code >= 400 && code < 500:
+		return AllowAll(), c.ttl
+	default:
+		return DisallowAll(), unreachableTTL
+	}
+}
+
+// siteRoot returns scheme://host for rawURL.
+func siteRoot(rawURL string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return "", fmt.Errorf("parse url %q: %w", rawURL, err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return "", fmt.Errorf("url %q is not absolute", rawURL)
+	}
+	return strings.ToLower(u.Scheme) + "://" + strings.ToLower(u.Host), nil
+}
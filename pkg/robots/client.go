@@ -0,0 +1,114 @@
+package robots
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/samvad-hq/samvad-news-harvester/pkg/httpclient"
+)
+
+// ErrDisallowed is returned (wrapped) when robots.txt forbids a request.
+var ErrDisallowed = errors.New("disallowed by robots.txt")
+
+// Client decorates an httpclient.Client, refusing URLs disallowed by robots.txt for the
+// request's User-Agent and spacing requests to each host by its Crawl-delay.
+type Client struct {
+	next    httpclient.Client
+	checker *Checker
+
+	mu       sync.Mutex
+	nextSlot map[string]time.Time
+}
+
+// NewClient wraps next with robots.txt enforcement backed by checker.
+func NewClient(next httpclient.Client, checker *Checker) *Client {
+	return &Client{
+		next:     next,
+		checker:  checker,
+		nextSlot: make(map[string]time.Time),
+	}
+}
+
+// Get performs a GET request if robots.txt allows it.
+func (c *Client) Get(ctx context.Context, url string, headers map[string]string) (httpclient.Response, error) {
+	return c.Do(ctx, httpclient.Request{Method: http.MethodGet, URL: url, Headers: headers})
+}
+
+// Do performs the request if robots.txt allows it.
+func (c *Client) Do(ctx context.Context, req httpclient.Request) (httpclient.Response, error) {
+	if err := c.admit(ctx, req); err != nil {
+		return nil, err
+	}
+	return c.next.Do(ctx, req)
+}
+
+// Stream performs the request if robots.txt allows it and returns the unread body.
+func (c *Client) Stream(ctx context.Context, req httpclient.Request) (httpclient.StreamResponse, error) {
+	if err := c.admit(ctx, req); err != nil {
+		return nil, err
+	}
+	return c.next.Stream(ctx, req)
+}
+
+// admit checks robots rules for the request and waits out the host's Crawl-delay.
+func (c *Client) admit(ctx context.Context, req httpclient.Request) error {
+	ua := headerValue(req.Headers, "User-Agent")
+
+	robots, err := c.checker.Robots(ctx, req.URL, ua)
+	if err != nil {
+		return err
+	}
+	if !robots.Allowed(req.URL, ua) {
+		return fmt.Errorf("%s: %w", req.URL, ErrDisallowed)
+	}
+
+	delay := robots.CrawlDelay(ua)
+	if delay <= 0 {
+		return nil
+	}
+	root, err := siteRoot(req.URL)
+	if err != nil {
+		return err
+	}
+	return c.wait(ctx, root, delay)
+}
+
+// wait reserves the next request slot for host and sleeps until it arrives.
+func (c *Client) wait(ctx context.Context, host string, delay time.Duration) error {
+	c.mu.Lock()
+	now := time.Now()
+	slot := c.nextSlot[host]
+	if slot.Before(now) {
+		slot = now
+	}
+	c.nextSlot[host] = slot.Add(delay)
+	c.mu.Unlock()
+
+	wait := time.Until(slot)
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// headerValue looks up a header case-insensitively.
+func headerValue(headers map[string]string, key string) string {
+	for k, v := range headers {
+		if strings.EqualFold(k, key) {
+			return v
+		}
+	}
+	return ""
+}
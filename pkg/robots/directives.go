@@ -0,0 +1,41 @@
+package robots
+
+import "strings"
+
+// Directives are page-level indexing instructions from meta robots tags or X-Robots-Tag headers.
+type Directives struct {
+	NoIndex  bool
+	NoFollow bool
+}
+
+// ParseDirectives merges meta robots / X-Robots-Tag values that apply to userAgent.
+// Values may be scoped to a crawler ("googlebot: noindex"); unscoped values apply to everyone.
+func ParseDirectives(userAgent string, values ...string) Directives {
+	ua := strings.ToLower(userAgent)
+
+	var d Directives
+	for _, value := range values {
+		scopeMatches := true
+		for _, token := range strings.Split(value, ",") {
+			token = strings.ToLower(strings.TrimSpace(token))
+			if name, rest, ok := strings.Cut(token, ":"); ok && !strings.Contains(name, " ") && name != "unavailable_after" {
+				name = strings.TrimSpace(name)
+				scopeMatches = name == "*" || (name != "" && strings.Contains(ua, name))
+				token = strings.TrimSpace(rest)
+			}
+			if !scopeMatches {
+				continue
+			}
+			switch token {
+			case "noindex":
+				d.NoIndex = true
+			case "nofollow":
+				d.NoFollow = true
+			case "none":
+				d.NoIndex = true
+				d.NoFollow = true
+			}
+		}
+	}
+	return d
+}
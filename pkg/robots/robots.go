@@ -0,0 +1,222 @@
+// Package robots implements robots.txt (RFC 9309) parsing, per-host caching and
+// an httpclient.Client decorator that enforces allow/disallow rules and Crawl-delay.
+package robots
+
+import (
+	"bufio"
+	"bytes"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxRobotsBytes is the parse limit recommended by RFC 9309.
+const maxRobotsBytes = 500 << 10 // 500 KiB
+
+// Robots holds the parsed contents of a robots.txt file.
+type Robots struct {
+	groups   []group
+	sitemaps []string
+	// allowAll/disallowAll short-circuit evaluation for unavailable or unreachable files.
+	allowAll    bool
+	disallowAll bool
+}
+
+type group struct {
+	agents     []string
+	rules      []rule
+	crawlDelay time.Duration
+}
+
+type rule struct {
+	allow   bool
+	pattern string
+}
+
+// AllowAll returns a Robots that permits every path (used when robots.txt is missing).
+func AllowAll() *Robots { return &Robots{allowAll: true} }
+
+// DisallowAll returns a Robots that forbids every path (used when robots.txt is unreachable).
+func DisallowAll() *Robots { return &Robots{disallowAll: true} }
+
+// Parse decodes a robots.txt body. Unknown lines are ignored.
+func Parse(data []byte) *Robots {
+	if len(data) > maxRobotsBytes {
+		data = data[:maxRobotsBytes]
+	}
+
+	r := &Robots{}
+	cur := -1 // index of the group being filled
+	lastWasAgent := false
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64<<10), maxRobotsBytes)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			if cur < 0 || !lastWasAgent {
+				r.groups = append(r.groups, group{})
+				cur = len(r.groups) - 1
+			}
+			r.groups[cur].agents = append(r.groups[cur].agents, strings.ToLower(value))
+			lastWasAgent = true
+			continue
+		case "allow", "disallow":
+			if cur >= 0 {
+				r.groups[cur].rules = append(r.groups[cur].rules, rule{allow: key == "allow", pattern: value})
+			}
+		case "crawl-delay":
+			if cur >= 0 {
+				if secs, err := strconv.ParseFloat(value, 64); err == nil && secs > 0 {
+					r.groups[cur].crawlDelay = time.Duration(secs * float64(time.Second))
+				}
+			}
+		case "sitemap":
+			if value != "" {
+				r.sitemaps = append(r.sitemaps, value)
+			}
+		}
+		lastWasAgent = false
+	}
+	return r
+}
+
+// Sitemaps returns the Sitemap: URLs declared in the file.
+func (r *Robots) Sitemaps() []string {
+	if r == nil {
+		return nil
+	}
+	out := make([]string, len(r.sitemaps))
+	copy(out, r.sitemaps)
+	return out
+}
+
+// Allowed reports whether userAgent may fetch rawURL (path and query are evaluated).
+func (r *Robots) Allowed(rawURL, userAgent string) bool {
+	if r == nil || r.allowAll {
+		return true
+	}
+
+	target := "/"
+	if u, err := url.Parse(rawURL); err == nil {
+		target = u.EscapedPath()
+		if target == "" {
+			target = "/"
+		}
+		if u.RawQuery != "" {
+			target += "?" + u.RawQuery
+		}
+	}
+	if target == "/robots.txt" {
+		return true
+	}
+	if r.disallowAll {
+		return false
+	}
+
+	rules, _ := r.rulesFor(userAgent)
+	allowed, bestLen := true, -1
+	for _, rl := range rules {
+		if rl.pattern == "" {
+			continue // empty Disallow allows everything
+		}
+		if !matchPattern(rl.pattern, target) {
+			continue
+		}
+		n := len(rl.pattern)
+		if n > bestLen || (n == bestLen && rl.allow) {
+			allowed, bestLen = rl.allow, n
+		}
+	}
+	return allowed
+}
+
+// CrawlDelay returns the Crawl-delay declared for userAgent, or zero.
+func (r *Robots) CrawlDelay(userAgent string) time.Duration {
+	if r == nil {
+		return 0
+	}
+	_, delay := r.rulesFor(userAgent)
+	return delay
+}
+
+// rulesFor merges the groups with the most specific user-agent match, falling back to "*".
+func (r *Robots) rulesFor(userAgent string) ([]rule, time.Duration) {
+	ua := strings.ToLower(userAgent)
+
+	bestLen := 0
+	var matched []group
+	for _, g := range r.groups {
+		for _, agent := range g.agents {
+			if agent == "" || agent == "*" || !strings.Contains(ua, agent) {
+				continue
+			}
+			switch {
+			case len(agent) > bestLen:
+				bestLen = len(agent)
+				matched = []group{g}
+			case len(agent) == bestLen:
+				matched = append(matched, g)
+			}
+		}
+	}
+	if len(matched) == 0 {
+		for _, g := range r.groups {
+			for _, agent := range g.agents {
+				if agent == "*" {
+					matched = append(matched, g)
+					break
+				}
+			}
+		}
+	}
+
+	var rules []rule
+	var delay time.Duration
+	for _, g := range matched {
+		rules = append(rules, g.rules...)
+		delay = max(delay, g.crawlDelay)
+	}
+	return rules, delay
+}
+
+// matchPattern matches a robots path pattern supporting "*" wildcards and a trailing "$" anchor.
+func matchPattern(pattern, target string) bool {
+	anchored := strings.HasSuffix(pattern, "$")
+	if anchored {
+		pattern = strings.TrimSuffix(pattern, "$")
+	}
+
+	parts := strings.Split(pattern, "*")
+	if !strings.HasPrefix(target, parts[0]) {
+		return false
+	}
+	pos := len(parts[0])
+	for i, part := range parts[1:] {
+		last := i == len(parts)-2
+		if last && anchored {
+			return strings.HasSuffix(target[pos:], part)
+		}
+		idx := strings.Index(target[pos:], part)
+		if idx < 0 {
+			return false
+		}
+		pos += idx + len(part)
+	}
+	if anchored {
+		return pos == len(target)
+	}
+	return true
+}
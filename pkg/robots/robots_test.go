@@ -0,0 +1,172 @@
+package robots
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/samvad-hq/samvad-news-harvester/pkg/httpclient"
+)
+
+// fakeClient serves canned responses keyed by URL and records request counts.
+type fakeClient struct {
+	responses map[string]*httpclient.BufferedResponse
+	err       error
+	calls     map[string]int
+}
+
+func (f *fakeClient) Get(ctx context.Context, url string, headers map[string]string) (httpclient.Response, error) {
+	return f.Do(ctx, httpclient.Request{Method: http.MethodGet, URL: url, Headers: headers})
+}
+
+func (f *fakeClient) Do(_ context.Context, req httpclient.Request) (httpclient.Response, error) {
+	if f.calls == nil {
+		f.calls = make(map[string]int)
+	}
+	f.calls[req.URL]++
+	if f.err != nil {
+		return nil, f.err
+	}
+	if resp, ok := f.responses[req.URL]; ok {
+		return resp, nil
+	}
+	return &httpclient.BufferedResponse{Status: http.StatusNotFound}, nil
+}
+
+func (f *fakeClient) Stream(context.Context, httpclient.Request) (httpclient.StreamResponse, error) {
+	return nil, errors.New("stream not supported by fake")
+}
+
+const sampleRobots = `
+# comment
+User-agent: *
+Disallow: /private/
+Allow: /private/open
+Disallow: /*.pdf$
+Crawl-delay: 2
+
+User-agent: SamvadBot
+User-agent: OtherBot
+Disallow: /bots-only
+Allow: /
+
+Sitemap: https://example.com/sitemap.xml
+`
+
+func TestParseAndAllowed(t *testing.T) {
+	r := Parse([]byte(sampleRobots))
+
+	cases := []struct {
+		url  string
+		ua   string
+		want bool
+	}{
+		{"https://example.com/news/1", "Mozilla/5.0", true},
+		{"https://example.com/private/x", "Mozilla/5.0", false},
+		{"https://example.com/private/open/x", "Mozilla/5.0", true},
+		{"https://example.com/files/report.pdf", "Mozilla/5.0", false},
+		{"https://example.com/files/report.pdf?x=1", "Mozilla/5.0", true},
+		{"https://example.com/private/x", "samvadbot/1.0", true},
+		{"https://example.com/bots-only/x", "SamvadBot/1.0", false},
+		{"https://example.com/robots.txt", "Mozilla/5.0", true},
+	}
+	for _, tc := range cases {
+		if got := r.Allowed(tc.url, tc.ua); got != tc.want {
+			t.Errorf("Allowed(%q, %q) = %v, want %v", tc.url, tc.ua, got, tc.want)
+		}
+	}
+
+	if got := r.CrawlDelay("Mozilla/5.0"); got != 2*time.Second {
+		t.Errorf("CrawlDelay(*) = %v", got)
+	}
+	if got := r.CrawlDelay("SamvadBot"); got != 0 {
+		t.Errorf("CrawlDelay(SamvadBot) = %v", got)
+	}
+	if sm := r.Sitemaps(); len(sm) != 1 || sm[0] != "https://example.com/sitemap.xml" {
+		t.Errorf("Sitemaps = %#v", sm)
+	}
+}
+
+func TestMatchPattern(t *testing.T) {
+	cases := []struct {
+		pattern, target string
+		want            bool
+	}{
+		{"/a", "/abc", true},
+		{"/a$", "/a", true},
+		{"/a$", "/ab", false},
+		{"/*/b", "/x/y/b/c", true},
+		{"/*.php$", "/index.php", true},
+		{"/*.php$", "/index.php5", false},
+		{"/b", "/a/b", false},
+	}
+	for _, tc := range cases {
+		if got := matchPattern(tc.pattern, tc.target); got != tc.want {
+			t.Errorf("matchPattern(%q, %q) = %v, want %v", tc.pattern, tc.target, got, tc.want)
+		}
+	}
+}
+
+func TestCheckerStatusHandling(t *testing.T) {
+	client := &fakeClient{responses: map[string]*httpclient.BufferedResponse{
+		"https://ok.example/robots.txt":   {Status: http.StatusOK, Data: []byte("User-agent: *\nDisallow: /")},
+		"https://down.example/robots.txt": {Status: http.StatusServiceUnavailable},
+	}}
+	checker := NewChecker(client, time.Hour)
+	ctx := context.Background()
+
+	if ok, _ := checker.Allowed(ctx, "https://ok.example/a", "bot"); ok {
+		t.Errorf("expected disallow from parsed rules")
+	}
+	if ok, _ := checker.Allowed(ctx, "https://missing.example/a", "bot"); !ok {
+		t.Errorf("expected 404 robots.txt to allow everything")
+	}
+	if ok, _ := checker.Allowed(ctx, "https://down.example/a", "bot"); ok {
+		t.Errorf("expected 5xx robots.txt to disallow everything")
+	}
+
+	_, _ = checker.Allowed(ctx, "https://ok.example/b", "bot")
+	if n := client.calls["https://ok.example/robots.txt"]; n != 1 {
+		t.Errorf("expected robots.txt to be cached, fetched %d times", n)
+	}
+
+	checker.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	_, _ = checker.Allowed(ctx, "https://ok.example/b", "bot")
+	if n := client.calls["https://ok.example/robots.txt"]; n != 2 {
+		t.Errorf("expected refetch after ttl, fetched %d times", n)
+	}
+}
+
+func TestClientRefusesDisallowedURLs(t *testing.T) {
+	client := &fakeClient{responses: map[string]*httpclient.BufferedResponse{
+		"https://example.com/robots.txt": {Status: http.StatusOK, Data: []byte("User-agent: *\nDisallow: /private")},
+		"https://example.com/public":     {Status: http.StatusOK, Data: []byte("ok")},
+	}}
+	wrapped := NewClient(client, NewChecker(client, time.Hour))
+
+	if _, err := wrapped.Get(context.Background(), "https://example.com/private/1", nil); !errors.Is(err, ErrDisallowed) {
+		t.Fatalf("expected ErrDisallowed, got %v", err)
+	}
+	resp, err := wrapped.Get(context.Background(), "https://example.com/public", nil)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if string(resp.Body()) != "ok" {
+		t.Fatalf("unexpected body %q", resp.Body())
+	}
+	if client.calls["https://example.com/private/1"] != 0 {
+		t.Fatalf("disallowed URL must not be requested")
+	}
+}
+
+func TestParseDirectives(t *testing.T) {
+	d := ParseDirectives("SamvadBot/1.0", "index, follow", "otherbot: noindex", "samvadbot: nofollow")
+	if d.NoIndex || !d.NoFollow {
+		t.Fatalf("unexpected directives %+v", d)
+	}
+	if d := ParseDirectives("", "NONE"); !d.NoIndex || !d.NoFollow {
+		t.Fatalf("expected none to imply noindex and nofollow, got %+v", d)
+	}
+}